@@ -0,0 +1,40 @@
+package structsql
+
+import . "github.com/cdvelop/tinystring"
+
+type annotate bool
+
+// Annotate passed to New prefixes every generated statement with a comment naming
+// the statement and its table, to find the statements of structsql in database logs:
+//
+//	/* structsql:insert users */ INSERT INTO users (id, name, email) VALUES ($1, $2, $3)
+//
+// Block comments are accepted by every supported database type.
+const Annotate annotate = true
+
+// statementVerbs maps the keyword starting a generated statement to its annotation
+var statementVerbs = [...]struct{ keyword, verb string }{
+	{"INSERT", "insert"},
+	{"UPDATE", "update"},
+	{"DELETE", "delete"},
+	{"SELECT", "select"},
+	{"CREATE", "create"},
+	{"DROP", "drop"},
+	{"TRUNCATE", "truncate"},
+	{"CALL", "call"},
+	{"EXEC", "call"},
+}
+
+// writeAnnotation writes into BuffWork the comment of the statement stmt on table
+// followed by stmt. table is left out when it could close the comment early.
+func writeAnnotation(c *Conv, stmt, table string) {
+	c.ResetBuffer(BuffWork)
+	c.WrString(BuffWork, "/* structsql:")
+	c.WrString(BuffWork, statementVerb(stmt))
+	if table != "" && Index(table, "*/") == -1 {
+		c.WrString(BuffWork, " ")
+		c.WrString(BuffWork, table)
+	}
+	c.WrString(BuffWork, " */ ")
+	c.WrString(BuffWork, stmt)
+}
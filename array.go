@@ -0,0 +1,34 @@
+package structsql
+
+import "github.com/cdvelop/tinyreflect"
+
+// bindArray binds the value of f, a slice field tagged with the array option, as
+// the slice itself, eg: Tags []string `db:"tags,array"` binds []string{"go", "sql"}
+// which drivers like pgx send as a PostgreSQL array. A nil slice is bound as NULL.
+// Other database types have no array columns and bind the field as JSON instead.
+func bindArray(row tinyreflect.Value, f *fieldInfo, iface *any) error {
+	v, err := fieldInterface(row, f)
+	if err != nil {
+		return err
+	}
+	isNil, err := tinyreflect.ValueOf(v).IsNil()
+	if err != nil {
+		return err
+	}
+	if isNil {
+		*iface = nil
+		return nil
+	}
+	*iface = v
+	return nil
+}
+
+// arrayType returns the PostgreSQL array column type of the slice type typ,
+// eg: TEXT[] for []string, or an empty string when its elements have no mapping.
+func (d dbType) arrayType(typ *tinyreflect.Type) string {
+	elem := d.columnType(typ.Elem())
+	if elem == "" {
+		return ""
+	}
+	return elem + "[]"
+}
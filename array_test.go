@@ -0,0 +1,58 @@
+package structsql_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestInsertArray(t *testing.T) {
+	b := Bookmark{ID: 1, URL: "https://go.dev", Tags: []string{"go", "docs"}}
+
+	tests := []struct {
+		name     string
+		configs  []any
+		row      Bookmark
+		wantArgs []any
+	}{
+		{"postgres", nil, b, []any{1, "https://go.dev", []string{"go", "docs"}}},
+		{"postgres nil", nil, Bookmark{ID: 2, URL: "https://go.dev"}, []any{2, "https://go.dev", nil}},
+		{"sqlite json", []any{structsql.SQLite, structsql.JSONMarshal(json.Marshal)}, b, []any{1, "https://go.dev", `["go","docs"]`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.configs...)
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := s.Insert(tt.row, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("Insert error: %v", err)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("Insert args mismatch:\n got: %#v\nwant: %#v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestUpdateArray(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	b := Bookmark{ID: 1, URL: "https://go.dev", Tags: []string{"go"}}
+	if err := s.Update(b, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+	wantSQL := "UPDATE bookmarks SET url=$1, tags=$2 WHERE id=$3"
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+	wantArgs := []any{"https://go.dev", []string{"go"}, 1}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %#v\nwant: %#v", gotArgs, wantArgs)
+	}
+}
@@ -0,0 +1,75 @@
+package structsql
+
+import (
+	"unsafe"
+
+	"github.com/cdvelop/tinyreflect"
+	. "github.com/cdvelop/tinystring"
+)
+
+// Predeclared types of the basic kinds, see basicType
+var (
+	boolType    = tinyreflect.TypeOf(false)
+	intType     = tinyreflect.TypeOf(int(0))
+	int8Type    = tinyreflect.TypeOf(int8(0))
+	int16Type   = tinyreflect.TypeOf(int16(0))
+	int32Type   = tinyreflect.TypeOf(int32(0))
+	int64Type   = tinyreflect.TypeOf(int64(0))
+	uintType    = tinyreflect.TypeOf(uint(0))
+	uint8Type   = tinyreflect.TypeOf(uint8(0))
+	uint16Type  = tinyreflect.TypeOf(uint16(0))
+	uint32Type  = tinyreflect.TypeOf(uint32(0))
+	uint64Type  = tinyreflect.TypeOf(uint64(0))
+	float32Type = tinyreflect.TypeOf(float32(0))
+	float64Type = tinyreflect.TypeOf(float64(0))
+	stringType  = tinyreflect.TypeOf("")
+)
+
+// basicType returns the predeclared type of kind, or nil when kind isn't basic
+func basicType(kind Kind) *tinyreflect.Type {
+	switch kind {
+	case K.Bool:
+		return boolType
+	case K.Int:
+		return intType
+	case K.Int8:
+		return int8Type
+	case K.Int16:
+		return int16Type
+	case K.Int32:
+		return int32Type
+	case K.Int64:
+		return int64Type
+	case K.Uint:
+		return uintType
+	case K.Uint8:
+		return uint8Type
+	case K.Uint16:
+		return uint16Type
+	case K.Uint32:
+		return uint32Type
+	case K.Uint64:
+		return uint64Type
+	case K.Float32:
+		return float32Type
+	case K.Float64:
+		return float64Type
+	case K.String:
+		return stringType
+	}
+	return nil
+}
+
+// bindBasic rebinds a named basic type held in iface, eg: type Status string, as its
+// predeclared type so values hold a plain string like an unnamed field would.
+// The value memory is shared, only the interface type changes. Call it after
+// bindValuer, named types implementing driver.Valuer bind their Value instead.
+func bindBasic(iface *any) {
+	e := (*tinyreflect.EmptyInterface)(unsafe.Pointer(iface))
+	if e.Type == nil {
+		return
+	}
+	if basic := basicType(e.Type.Kind()); basic != nil && basic != e.Type {
+		e.Type = basic
+	}
+}
@@ -0,0 +1,47 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestNamedStringColumn(t *testing.T) {
+	tk := Ticket{ID: 1, Stage: "open"}
+	wantSQL := "INSERT INTO tickets (id, stage) VALUES ($1, $2)"
+	wantArgs := []any{1, "open"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Insert(tk, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	// DeepEqual fails on Stage("open"), values must hold the plain string
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %#v\nwant: %#v", gotArgs, wantArgs)
+	}
+
+	wantArgs = []any{"open", 1}
+	if err := s.Update(&tk, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %#v\nwant: %#v", gotArgs, wantArgs)
+	}
+
+	wantSQL = "CREATE TABLE tickets (id BIGINT PRIMARY KEY, stage TEXT)"
+	if err := s.CreateTable(tk, &gotSQL); err != nil {
+		t.Fatalf("CreateTable error: %v", err)
+	}
+	if gotSQL != wantSQL {
+		t.Fatalf("CreateTable SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
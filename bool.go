@@ -0,0 +1,22 @@
+package structsql
+
+type boolAsInt bool
+
+// BoolAsInt passed to New binds bool field values as the integers 1 and 0 on
+// SQLite, which has no boolean type and stores them in INTEGER columns.
+// PostgreSQL, MySQL and SQL Server keep binding the Go bool.
+const BoolAsInt boolAsInt = true
+
+// bindBool replaces a bool held in iface with 1 or 0 when BoolAsInt applies
+func (s *Structsql) bindBool(iface *any) {
+	if !s.boolAsInt || s.dbType != SQLite {
+		return
+	}
+	if b, ok := (*iface).(bool); ok {
+		if b {
+			*iface = 1
+		} else {
+			*iface = 0
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestBoolAsInt(t *testing.T) {
+	tests := []struct {
+		name     string
+		configs  []any
+		row      Feature
+		wantArgs []any
+	}{
+		{"sqlite true", []any{structsql.SQLite, structsql.BoolAsInt}, Feature{ID: 1, Name: "beta", Active: true}, []any{1, "beta", 1}},
+		{"sqlite false", []any{structsql.SQLite, structsql.BoolAsInt}, Feature{ID: 1, Name: "beta"}, []any{1, "beta", 0}},
+		{"postgres", []any{structsql.BoolAsInt}, Feature{ID: 1, Name: "beta", Active: true}, []any{1, "beta", true}},
+		{"sqlite default", []any{structsql.SQLite}, Feature{ID: 1, Name: "beta", Active: true}, []any{1, "beta", true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.configs...)
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := s.Insert(tt.row, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("Insert error: %v", err)
+			}
+
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("Insert args mismatch:\n got: %#v\nwant: %#v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestBoolAsIntPredicates(t *testing.T) {
+	s := structsql.New(structsql.SQLite, structsql.BoolAsInt)
+
+	tests := []struct {
+		name     string
+		build    func(sql *string, args *[]any) error
+		wantArgs []any
+	}{
+		{"SelectByColumn", func(sql *string, args *[]any) error {
+			return s.SelectByColumn(Feature{}, "active", true, sql, args)
+		}, []any{1}},
+		{"SelectIn", func(sql *string, args *[]any) error {
+			return s.SelectIn(Feature{}, "active", []any{true, false}, sql, args)
+		}, []any{1, 0}},
+		{"SelectWhere", func(sql *string, args *[]any) error {
+			return s.SelectWhere(Feature{}, structsql.NewWhere().Eq("active", true).In("active", false), sql, args)
+		}, []any{1, 0}},
+		{"CountBy", func(sql *string, args *[]any) error {
+			return s.CountBy(Feature{Active: true}, "active", sql, args)
+		}, []any{1}},
+		{"DeleteByIDs", func(sql *string, args *[]any) error {
+			return s.DeleteByIDs(Feature{}, []any{true, false}, sql, args)
+		}, []any{1, 0}},
+		{"UpdateMap", func(sql *string, args *[]any) error {
+			return s.UpdateMap("features", 7, map[string]any{"active": true}, sql, args)
+		}, []any{1, 7}},
+		{"InsertMap", func(sql *string, args *[]any) error {
+			return s.InsertMap("features", map[string]any{"active": false, "name": "beta"}, sql, args)
+		}, []any{0, "beta"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := tt.build(&gotSQL, &gotArgs); err != nil {
+				t.Fatalf("%s error: %v", tt.name, err)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("%s args mismatch:\n got: %#v\nwant: %#v", tt.name, gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package structsql
+
+import (
+	"database/sql/driver"
+	"unsafe"
+
+	"github.com/cdvelop/tinyreflect"
+	. "github.com/cdvelop/tinystring"
+)
+
+// bindBytes rebinds a byte array held in iface, eg: a [16]byte UUID key, as a []byte
+// since database/sql only accepts byte slices. The slice views the array memory like
+// every other bound field: it belongs to the private copy of the struct the verbs
+// bind from, so later writes by the caller don't reach it. A []byte field is bound
+// as is, the caller owns its backing array as with any database/sql argument.
+// Types implementing driver.Valuer such as uuid.UUID are left to bindValuer.
+func bindBytes(iface *any) {
+	typ := tinyreflect.TypeOf(*iface)
+	if typ == nil || typ.Kind() != K.Array {
+		return
+	}
+	if _, ok := (*iface).(driver.Valuer); ok {
+		return
+	}
+	arr := typ.ArrayType()
+	if arr == nil || arr.Elem.Kind() != K.Uint8 {
+		return
+	}
+	e := (*tinyreflect.EmptyInterface)(unsafe.Pointer(iface))
+	*iface = unsafe.Slice((*byte)(e.Data), arr.Len)
+}
@@ -0,0 +1,98 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+var deviceID = [16]byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+
+func TestByteArrayPrimaryKey(t *testing.T) {
+	d := Device{ID: deviceID, Name: "sensor"}
+	id := deviceID[:]
+
+	tests := []struct {
+		name     string
+		verb     func(s *structsql.Structsql, sql *string, values *[]any) error
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"insert", func(s *structsql.Structsql, sql *string, values *[]any) error { return s.Insert(d, sql, values) },
+			"INSERT INTO devices (id, name) VALUES ($1, $2)", []any{id, "sensor"}},
+		{"update", func(s *structsql.Structsql, sql *string, values *[]any) error { return s.Update(d, sql, values) },
+			"UPDATE devices SET name=$1 WHERE id=$2", []any{"sensor", id}},
+		{"delete", func(s *structsql.Structsql, sql *string, values *[]any) error { return s.Delete(d, sql, values) },
+			"DELETE FROM devices WHERE id=$1", []any{id}},
+		{"select", func(s *structsql.Structsql, sql *string, values *[]any) error { return s.Select(d, sql, values) },
+			"SELECT id, name FROM devices WHERE id=$1", []any{id}},
+	}
+
+	s := structsql.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+			if err := tt.verb(s, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("args mismatch:\n got: %v\nwant: %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestValuerPrimaryKey(t *testing.T) {
+	tk := Token{ID: UUID(deviceID), Owner: "alice"}
+	wantSQL := "UPDATE tokens SET owner=$1 WHERE id=$2"
+	wantArgs := []any{"alice", "123e4567e89b12d3a456426614174000"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Update(tk, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	// Bound through the Value method of UUID, not as a byte slice
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+// TestBytesDontAlias changes the byte array after Insert, the bound []byte views
+// the private copy of the struct and must keep the bytes at the time of the call
+func TestBytesDontAlias(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	d := Device{ID: deviceID, Name: "sensor"}
+	if err := s.Insert(&d, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	d.ID[0] = 0xff
+	if want := []any{deviceID[:], "sensor"}; !reflect.DeepEqual(gotArgs, want) {
+		t.Fatalf("Insert args changed with the array:\n got: %v\nwant: %v", gotArgs, want)
+	}
+
+	rows := []Device{{ID: deviceID, Name: "sensor"}}
+	if err := s.InsertBatch(rows, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("InsertBatch error: %v", err)
+	}
+	rows[0].ID[0] = 0xff
+	if want := []any{deviceID[:], "sensor"}; !reflect.DeepEqual(gotArgs, want) {
+		t.Fatalf("InsertBatch args changed with the array:\n got: %v\nwant: %v", gotArgs, want)
+	}
+}
@@ -0,0 +1,28 @@
+package structsql
+
+import . "github.com/cdvelop/tinystring"
+
+type strictCapacity bool
+
+// StrictCapacity passed to New makes every method that fills a values slice return
+// ErrValuesCapacity when the slice can't hold every value, instead of replacing it
+// with a new slice. Use it in tests to catch undersized buffers:
+//
+//	s := structsql.New(structsql.StrictCapacity)
+//	values := make([]any, 0, 3) // enough for User{ID, Name, Email}
+//
+// By default a short slice is reallocated and the caller's buffer abandoned.
+const StrictCapacity strictCapacity = true
+
+// ensureCapacity makes room for n values, emptying values first
+func (s *Structsql) ensureCapacity(values *[]any, n int) error {
+	*values = (*values)[:0]
+	if cap(*values) >= n {
+		return nil
+	}
+	if s.strictCapacity {
+		return errDetail(ErrValuesCapacity, Convert(n).String())
+	}
+	*values = make([]any, 0, n)
+	return nil
+}
@@ -0,0 +1,63 @@
+package structsql
+
+// ColumnCase selects how field names without a db tag name become column names
+type ColumnCase string
+
+const (
+	Lower ColumnCase = "lower" // FirstName -> firstname (default)
+	Snake ColumnCase = "snake" // FirstName -> first_name, HTTPServer -> http_server
+	AsIs  ColumnCase = "as_is" // FirstName -> FirstName
+)
+
+// WordSeparator passed to New replaces the "_" joining the words of generated
+// identifiers: Snake column names, CreateIndexes index names and the words of a
+// table name, of which PluralEnglish inflects the last. eg: WordSeparator("$")
+// generates first$name. The Schema is written verbatim, it isn't generated, and the
+// "." qualifying table names is SQL syntax and is kept.
+type WordSeparator string
+
+// snakeCase converts a Go field name to snake_case joining words with sep. Acronyms
+// are kept together, a new word starts at a lower to upper change or before the last
+// upper of an acronym followed by a lowercase letter, eg: UserID -> user_id, HTTPServer -> http_server
+func snakeCase(name, sep string) string {
+	buf := make([]byte, 0, len(name)+4)
+	for i := 0; i < len(name); i++ {
+		b := name[i]
+		if isUpper(b) {
+			if i > 0 {
+				prev := name[i-1]
+				nextLower := i+1 < len(name) && isLower(name[i+1])
+				if isLower(prev) || isDigit(prev) || (isUpper(prev) && nextLower) {
+					buf = append(buf, sep...)
+				}
+			}
+			b += 'a' - 'A'
+		}
+		buf = append(buf, b)
+	}
+	return string(buf)
+}
+
+// equalFold reports whether the ASCII strings a and b are equal ignoring case
+func equalFold(a, b string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := 0; i < len(a); i++ {
+		x, y := a[i], b[i]
+		if isUpper(x) {
+			x += 'a' - 'A'
+		}
+		if isUpper(y) {
+			y += 'a' - 'A'
+		}
+		if x != y {
+			return false
+		}
+	}
+	return true
+}
+
+func isUpper(b byte) bool { return b >= 'A' && b <= 'Z' }
+func isLower(b byte) bool { return b >= 'a' && b <= 'z' }
+func isDigit(b byte) bool { return b >= '0' && b <= '9' }
@@ -0,0 +1,81 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestColumnCase(t *testing.T) {
+	tests := []struct {
+		name    string
+		naming  structsql.ColumnCase
+		wantSQL string
+	}{
+		{"lower", structsql.Lower, "SELECT id, userid, createdat, httpserver FROM sessions"},
+		{"snake", structsql.Snake, "SELECT id, user_id, created_at, http_server FROM sessions"},
+		{"as is", structsql.AsIs, `SELECT "ID", "UserID", "CreatedAt", "HTTPServer" FROM sessions`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.naming)
+			var gotSQL string
+			if err := s.SelectAll(Session{}, &gotSQL); err != nil {
+				t.Fatalf("SelectAll error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestColumnCaseTagWins(t *testing.T) {
+	wantSQL := `SELECT user_id, first_name, created_at, "Bio" FROM profiles`
+
+	s := structsql.New(structsql.AsIs)
+	var gotSQL string
+	if err := s.SelectAll(Profile{}, &gotSQL); err != nil {
+		t.Fatalf("SelectAll error: %v", err)
+	}
+	if gotSQL != wantSQL {
+		t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestWordSeparator(t *testing.T) {
+	s := structsql.New(structsql.Snake, structsql.WordSeparator("$"))
+
+	wantSQL := "SELECT id, user$id, created$at, http$server FROM sessions"
+	var gotSQL string
+	if err := s.SelectAll(Session{}, &gotSQL); err != nil {
+		t.Fatalf("SelectAll error: %v", err)
+	}
+	if gotSQL != wantSQL {
+		t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	want := []string{
+		"CREATE UNIQUE INDEX idx$tenants$email ON tenants (email)",
+		"CREATE INDEX idx$tenants$tenant_id ON tenants (tenant_id)",
+	}
+	var got []string
+	if err := s.CreateIndexes(Tenant{}, &got); err != nil {
+		t.Fatalf("CreateIndexes error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("CreateIndexes mismatch:\n got: %q\nwant: %q", got, want)
+	}
+
+	// The schema is written verbatim
+	s = structsql.New(structsql.Snake, structsql.Schema("app_data"), structsql.WordSeparator("$"))
+	wantSQL = "SELECT id, user$id, created$at, http$server FROM app_data.sessions"
+	if err := s.SelectAll(Session{}, &gotSQL); err != nil {
+		t.Fatalf("SelectAll error: %v", err)
+	}
+	if gotSQL != wantSQL {
+		t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
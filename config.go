@@ -0,0 +1,94 @@
+package structsql
+
+// Config lists every setting of New as a named field, an alternative to the
+// variadic options that the compiler checks. Zero fields keep the New defaults:
+//
+//	s := structsql.NewWith(structsql.Config{Dialect: structsql.SQLite, ColumnCase: structsql.Snake})
+type Config struct {
+	Dialect              dbType          // PostgreSQL when empty
+	TableNaming          TableNaming     // PluralEnglish when empty
+	ColumnCase           ColumnCase      // Lower when empty
+	Schema               string          // table qualifier, see Schema
+	QuoteMode            QuoteMode       // database type default when empty
+	NowFunc              NowFunc         // time.Now when nil
+	TimeFormat           TimeFormat      // see TimeFormat
+	ExcludeSoftDeleted   bool            // see ExcludeSoftDeleted
+	StrictCapacity       bool            // see StrictCapacity
+	AllowFullTableDelete bool            // see AllowFullTableDelete
+	SQLiteRowID          bool            // see SQLiteRowID
+	SQLiteReturning      bool            // see SQLiteReturning
+	BoolAsInt            bool            // see BoolAsInt
+	Annotate             bool            // see Annotate
+	PlaceholderStart     int             // 1 when zero, see PlaceholderStart
+	WordSeparator        string          // "_" when empty, see WordSeparator
+	PrimaryKeyNames      PrimaryKeyNames // see PrimaryKeyNames
+	SQLHook              SQLHook         // see SQLHook
+	PKFunc               PKFunc          // IDorPrimaryKey conventions when nil, see PKFunc
+	JSONMarshal          JSONMarshal     // required by json fields, see JSONMarshal
+}
+
+// NewWith returns a Structsql configured by cfg, see Config
+func NewWith(cfg Config) *Structsql {
+	configs := make([]any, 0, 16)
+	if cfg.Dialect != "" {
+		configs = append(configs, cfg.Dialect)
+	}
+	if cfg.TableNaming != "" {
+		configs = append(configs, cfg.TableNaming)
+	}
+	if cfg.ColumnCase != "" {
+		configs = append(configs, cfg.ColumnCase)
+	}
+	if cfg.Schema != "" {
+		configs = append(configs, Schema(cfg.Schema))
+	}
+	if cfg.QuoteMode != "" {
+		configs = append(configs, cfg.QuoteMode)
+	}
+	if cfg.NowFunc != nil {
+		configs = append(configs, cfg.NowFunc)
+	}
+	if cfg.TimeFormat != "" {
+		configs = append(configs, cfg.TimeFormat)
+	}
+	if cfg.ExcludeSoftDeleted {
+		configs = append(configs, ExcludeSoftDeleted)
+	}
+	if cfg.StrictCapacity {
+		configs = append(configs, StrictCapacity)
+	}
+	if cfg.AllowFullTableDelete {
+		configs = append(configs, AllowFullTableDelete)
+	}
+	if cfg.SQLiteRowID {
+		configs = append(configs, SQLiteRowID)
+	}
+	if cfg.SQLiteReturning {
+		configs = append(configs, SQLiteReturning)
+	}
+	if cfg.BoolAsInt {
+		configs = append(configs, BoolAsInt)
+	}
+	if cfg.Annotate {
+		configs = append(configs, Annotate)
+	}
+	if cfg.PlaceholderStart != 0 {
+		configs = append(configs, PlaceholderStart(cfg.PlaceholderStart))
+	}
+	if cfg.WordSeparator != "" {
+		configs = append(configs, WordSeparator(cfg.WordSeparator))
+	}
+	if cfg.PrimaryKeyNames != nil {
+		configs = append(configs, cfg.PrimaryKeyNames)
+	}
+	if cfg.SQLHook != nil {
+		configs = append(configs, cfg.SQLHook)
+	}
+	if cfg.PKFunc != nil {
+		configs = append(configs, cfg.PKFunc)
+	}
+	if cfg.JSONMarshal != nil {
+		configs = append(configs, cfg.JSONMarshal)
+	}
+	return New(configs...)
+}
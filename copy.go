@@ -0,0 +1,30 @@
+package structsql
+
+import (
+	"unsafe"
+
+	"github.com/cdvelop/tinyreflect"
+)
+
+// copyInterface replaces the value held by address in iface with a private copy,
+// so the values bound from it don't change when the caller later writes to the
+// memory it came from, eg: the struct behind Insert(&u) or an element of a slice.
+func copyInterface(iface *any) {
+	e := (*tinyreflect.EmptyInterface)(unsafe.Pointer(iface))
+	if e.Type == nil || !e.Type.IfaceIndir() {
+		return // held by value in the interface, already a copy
+	}
+	p := unsafe_New(e.Type)
+	typedmemmove(e.Type, p, e.Data)
+	e.Data = p
+}
+
+// copyValue returns a Value of a private copy of v, see copyInterface
+func copyValue(v tinyreflect.Value) (tinyreflect.Value, error) {
+	iface, err := v.Interface()
+	if err != nil {
+		return tinyreflect.Value{}, err
+	}
+	copyInterface(&iface)
+	return tinyreflect.ValueOf(iface), nil
+}
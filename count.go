@@ -0,0 +1,146 @@
+package structsql
+
+import (
+	"github.com/cdvelop/tinyreflect"
+	. "github.com/cdvelop/tinystring"
+)
+
+// Count generates SELECT COUNT(*) FROM users
+func (s *Structsql) Count(structTable any, sql *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT COUNT(*) FROM ")
+	s.quoteTable(tableStr, c)
+	s.writeNotDeleted(c, info, false)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CountBy generates SELECT COUNT(*) FROM users WHERE column=$1 where values
+// receives the current value of column in structTable, eg: User{Email: "a@b.c"}
+func (s *Structsql) CountBy(structTable any, column string, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	v := structTable
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	colIndex := info.columnIndex(column)
+	if colIndex == -1 {
+		return errDetail(ErrUnknownColumn, column)
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT COUNT(*) FROM ")
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " WHERE ")
+	s.quote(info.fields[colIndex].Name, c)
+	c.WrString(BuffOut, "=")
+	s.placeholder(1, c)
+	s.writeNotDeleted(c, info, true)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	// Populate values
+	if err := s.ensureCapacity(values, 1); err != nil {
+		return err
+	}
+	val := tinyreflect.ValueOf(v)
+	fieldVal, err := info.fields[colIndex].value(val)
+	if err != nil {
+		return err
+	}
+	var iface any
+	if err := bindValue(fieldVal, &iface); err != nil {
+		return err
+	}
+	s.appendArgs(values, iface)
+
+	return nil
+}
+
+// CountWhere generates SELECT COUNT(*) FROM users WHERE name=$1 AND age>$2
+// with the condition values of where appended to values in clause order.
+func (s *Structsql) CountWhere(structTable any, where *Where, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT COUNT(*) FROM ")
+	s.quoteTable(tableStr, c)
+
+	if err := s.ensureCapacity(values, where.argCount()); err != nil {
+		return err
+	}
+	if _, err := s.writeWhere(c, info, where, 1, values); err != nil {
+		return err
+	}
+	s.writeNotDeleted(c, info, where != nil && len(where.conds) > 0)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestCount(t *testing.T) {
+	wantSQL := "SELECT COUNT(*) FROM users"
+
+	s := structsql.New() // Default PostgreSQL
+	var gotSQL string
+
+	err := s.Count(User{}, &gotSQL)
+	if err != nil {
+		t.Fatalf("Count error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Count SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestCountSQLite(t *testing.T) {
+	wantSQL := "SELECT COUNT(*) FROM users"
+
+	s := structsql.New(structsql.SQLite)
+	var gotSQL string
+
+	err := s.Count(User{}, &gotSQL)
+	if err != nil {
+		t.Fatalf("Count error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Count SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestCountBy(t *testing.T) {
+	u := User{Email: "alice@example.com"}
+	wantSQL := "SELECT COUNT(*) FROM users WHERE email=$1"
+	wantArgs := []any{"alice@example.com"}
+
+	s := structsql.New() // Default PostgreSQL
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.CountBy(u, "email", &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("CountBy error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("CountBy SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("CountBy args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestCountBySQLite(t *testing.T) {
+	u := User{Email: "alice@example.com"}
+	wantSQL := "SELECT COUNT(*) FROM users WHERE email=?"
+	wantArgs := []any{"alice@example.com"}
+
+	s := structsql.New(structsql.SQLite)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.CountBy(u, "email", &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("CountBy error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("CountBy SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("CountBy args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestCountByUnknownColumn(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.CountBy(User{}, "age", &gotSQL, &gotArgs); err == nil {
+		t.Fatal("CountBy expected error for unknown column, got nil")
+	}
+}
@@ -1,5 +1,13 @@
 package structsql_test
 
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"time"
+)
+
 type User struct {
 	ID    int    `db:"id,pk"`
 	Name  string `db:"name"`
@@ -9,3 +17,593 @@ type User struct {
 func (u User) StructName() string {
 	return "User"
 }
+
+type Profile struct {
+	UserID    int    `db:"user_id,pk"`
+	FirstName string `db:"first_name"`
+	CreatedAt string `db:"created_at"`
+	Bio       string
+}
+
+func (p Profile) StructName() string {
+	return "Profile"
+}
+
+type Person struct {
+	ID   int
+	Name string
+}
+
+func (p Person) StructName() string {
+	return "Person"
+}
+
+type Address struct {
+	ID     int
+	Street string
+}
+
+func (a Address) StructName() string {
+	return "Address"
+}
+
+type Category struct {
+	ID   int
+	Name string
+}
+
+func (c Category) StructName() string {
+	return "Category"
+}
+
+type Box struct {
+	ID   int
+	Size int
+}
+
+func (b Box) StructName() string {
+	return "Box"
+}
+
+type Quiz struct {
+	ID    int
+	Title string
+}
+
+func (q Quiz) StructName() string {
+	return "Quiz"
+}
+
+type Canvas struct {
+	ID    int
+	Width int
+}
+
+func (c Canvas) StructName() string {
+	return "Canvas"
+}
+
+type Alias struct {
+	ID   int
+	Name string
+}
+
+func (a Alias) StructName() string {
+	return "Alias"
+}
+
+type Product struct {
+	IDProduct int
+	Name      string
+}
+
+func (p Product) StructName() string {
+	return "Product"
+}
+
+// AutoUser maps to the users table with a database generated primary key
+type AutoUser struct {
+	ID    int    `db:"id,pk,auto"`
+	Name  string `db:"name"`
+	Email string `db:"email"`
+}
+
+func (u AutoUser) StructName() string {
+	return "User"
+}
+
+type Member struct {
+	ID       int
+	Password string `db:"-"`
+	Email    string
+}
+
+func (m Member) StructName() string {
+	return "Member"
+}
+
+// Contact has an optional column mapped to a pointer field
+type Contact struct {
+	ID    int
+	Name  string
+	Phone *string
+}
+
+func (c Contact) StructName() string {
+	return "Contact"
+}
+
+// Document uses a version column for optimistic locking
+type Document struct {
+	ID      int    `db:"id,pk"`
+	Title   string `db:"title"`
+	Version int    `db:"version"`
+}
+
+func (d Document) StructName() string {
+	return "Document"
+}
+
+// Post has timestamp columns managed by structsql
+type Post struct {
+	ID        int       `db:"id,pk"`
+	Title     string    `db:"title"`
+	CreatedAt time.Time `db:"created_at,autocreate"`
+	UpdatedAt time.Time `db:"updated_at,autoupdate"`
+}
+
+func (p Post) StructName() string {
+	return "Post"
+}
+
+// Receipt has a sequence number generated by the database besides its key
+type Receipt struct {
+	ID   int    `db:"id,pk"`
+	Seq  int64  `db:"seq,auto"`
+	Memo string `db:"memo"`
+}
+
+func (r Receipt) StructName() string {
+	return "Receipt"
+}
+
+// Visit has no column an upsert could update, its only non-key column is autocreate
+type Visit struct {
+	ID        int       `db:"id,pk"`
+	CreatedAt time.Time `db:"created_at,autocreate"`
+}
+
+func (v Visit) StructName() string {
+	return "Visit"
+}
+
+// Event has a time.Time column set by the caller
+type Event struct {
+	ID        int       `db:"id,pk"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+func (e Event) StructName() string {
+	return "Event"
+}
+
+// Setting stores structured data in a JSON column
+type Setting struct {
+	ID   int            `db:"id,pk"`
+	Meta map[string]any `db:"meta,json"`
+}
+
+func (s Setting) StructName() string {
+	return "Setting"
+}
+
+// Device has a UUID primary key stored as a raw byte array
+type Device struct {
+	ID   [16]byte `db:"id,pk"`
+	Name string   `db:"name"`
+}
+
+func (d Device) StructName() string {
+	return "Device"
+}
+
+// UUID mimics uuid.UUID, a byte array converted by its driver.Valuer
+type UUID [16]byte
+
+func (u UUID) Value() (driver.Value, error) {
+	return hex.EncodeToString(u[:]), nil
+}
+
+// Token has a UUID primary key implementing driver.Valuer
+type Token struct {
+	ID    UUID   `db:"id,pk"`
+	Owner string `db:"owner"`
+}
+
+func (t Token) StructName() string {
+	return "Token"
+}
+
+// Status is an enum bound as its name through driver.Valuer
+type Status int
+
+const (
+	StatusDraft Status = iota + 1
+	StatusPaid
+)
+
+func (st Status) Value() (driver.Value, error) {
+	switch st {
+	case StatusDraft:
+		return "draft", nil
+	case StatusPaid:
+		return "paid", nil
+	}
+	return nil, errors.New("invalid status")
+}
+
+// Invoice has a field converted by its driver.Valuer
+type Invoice struct {
+	ID     int    `db:"id,pk"`
+	Status Status `db:"status"`
+}
+
+func (i Invoice) StructName() string {
+	return "Invoice"
+}
+
+// Account is soft deleted through its deleted_at column
+type Account struct {
+	ID        int        `db:"id,pk"`
+	Name      string     `db:"name"`
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+func (a Account) StructName() string {
+	return "Account"
+}
+
+// Model is a base struct embedded by other models
+type Model struct {
+	ID        int       `db:"id,pk"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type Article struct {
+	Model
+	Title string `db:"title"`
+}
+
+func (a Article) StructName() string {
+	return "Article"
+}
+
+// Clash declares a column already promoted from Model
+type Clash struct {
+	Model
+	Created time.Time `db:"created_at"`
+}
+
+func (c Clash) StructName() string {
+	return "Clash"
+}
+
+// Keyword is mapped to a column named after a reserved word
+type Keyword struct {
+	ID    int    `db:"id,pk"`
+	Order string `db:"order"`
+}
+
+func (k Keyword) StructName() string {
+	return "Keyword"
+}
+
+// Clause has untagged reserved word fields named by AsIs
+type Clause struct {
+	ID    int `db:",pk"`
+	Order string
+	When  string
+}
+
+func (c Clause) StructName() string {
+	return "Clause"
+}
+
+// Session has untagged multi word fields named by ColumnCase
+type Session struct {
+	ID         int
+	UserID     int
+	CreatedAt  time.Time
+	HTTPServer string
+}
+
+func (s Session) StructName() string {
+	return "Session"
+}
+
+// Note has no primary key field
+type Note struct {
+	Text string
+}
+
+func (n Note) StructName() string {
+	return "Note"
+}
+
+// Item instantiations are distinct struct types sharing the items table,
+// used to fill the type caches with many models
+type Item[T any] struct {
+	ID    int
+	Value T
+}
+
+func (i Item[T]) StructName() string {
+	return "Item"
+}
+
+// Customer is stored in the accounts table through TableName
+type Customer struct {
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func (c Customer) StructName() string {
+	return "Customer"
+}
+
+func (c Customer) TableName() string {
+	return "accounts"
+}
+
+// Acct declares TableName with a pointer receiver, which is rejected
+type Acct struct {
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func (a Acct) StructName() string {
+	return "Acct"
+}
+
+func (a *Acct) TableName() string {
+	return "legacy_accounts"
+}
+
+// Tenant has indexed columns for CreateIndexes
+type Tenant struct {
+	ID       int    `db:"id,pk"`
+	Email    string `db:"email,unique"`
+	TenantID int    `db:"tenant_id,index"`
+	Name     string `db:"name"`
+}
+
+func (t Tenant) StructName() string {
+	return "Tenant"
+}
+
+// Subscription has column constraints for CreateTable
+type Subscription struct {
+	ID     int    `db:"id,pk"`
+	Name   string `db:"name,notnull"`
+	Status string `db:"status,notnull,default='active'"`
+}
+
+func (s Subscription) StructName() string {
+	return "Subscription"
+}
+
+// Stage is a named string type stored in a text column
+type Stage string
+
+// Ticket has a column of the named string type Stage
+type Ticket struct {
+	ID    int   `db:"id,pk"`
+	Stage Stage `db:"stage"`
+}
+
+func (t Ticket) StructName() string {
+	return "Ticket"
+}
+
+// ledgerTableNames counts the TableName calls of Ledger, made on a table name cache miss
+var ledgerTableNames int
+
+// Ledger is stored in the books table through TableName
+type Ledger struct {
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func (l Ledger) StructName() string {
+	return "Ledger"
+}
+
+func (l Ledger) TableName() string {
+	ledgerTableNames++
+	return "books"
+}
+
+// Price overrides its column types with the type option
+type Price struct {
+	ID     int     `db:"id,pk,type=SMALLINT"`
+	Name   string  `db:"name,type=VARCHAR(255)"`
+	Amount float64 `db:"amount,type=DECIMAL(10,2),notnull"`
+}
+
+func (p Price) StructName() string {
+	return "Price"
+}
+
+// Transfer declares its primary key after the other fields
+type Transfer struct {
+	Memo   string `db:"memo"`
+	Amount int    `db:"amount"`
+	ID     int    `db:"id,pk"`
+}
+
+func (t Transfer) StructName() string {
+	return "Transfer"
+}
+
+// Entry declares a database generated primary key after the other fields
+type Entry struct {
+	Memo   string `db:"memo"`
+	Amount int    `db:"amount"`
+	ID     int    `db:"id,pk,auto"`
+}
+
+func (e Entry) StructName() string {
+	return "Entry"
+}
+
+// Feature has a bool column
+type Feature struct {
+	ID     int    `db:"id,pk"`
+	Name   string `db:"name"`
+	Active bool   `db:"active"`
+}
+
+func (f Feature) StructName() string {
+	return "Feature"
+}
+
+// Asset names its primary key uuid, found through PrimaryKeyNames
+type Asset struct {
+	UUID string
+	Name string
+}
+
+func (a Asset) StructName() string {
+	return "Asset"
+}
+
+// UserArchive has the columns of User and is stored in users_archive
+type UserArchive struct {
+	ID    int    `db:"id,pk"`
+	Name  string `db:"name"`
+	Email string `db:"email"`
+}
+
+func (u UserArchive) StructName() string {
+	return "UserArchive"
+}
+
+func (u UserArchive) TableName() string {
+	return "users_archive"
+}
+
+// Wide has more columns than the 32 kept on the stack by Insert and Update
+type Wide struct {
+	ID  int `db:"id,pk"`
+	C1  int
+	C2  int
+	C3  int
+	C4  int
+	C5  int
+	C6  int
+	C7  int
+	C8  int
+	C9  int
+	C10 int
+	C11 int
+	C12 int
+	C13 int
+	C14 int
+	C15 int
+	C16 int
+	C17 int
+	C18 int
+	C19 int
+	C20 int
+	C21 int
+	C22 int
+	C23 int
+	C24 int
+	C25 int
+	C26 int
+	C27 int
+	C28 int
+	C29 int
+	C30 int
+	C31 int
+	C32 int
+	C33 int
+	C34 int
+	C35 int
+	C36 int
+	C37 int
+	C38 int
+	C39 int
+}
+
+func (w Wide) StructName() string {
+	return "Wide"
+}
+
+// Order references users through a foreign key deleted in cascade
+type Order struct {
+	ID     int     `db:"id,pk"`
+	UserID int     `db:"user_id,notnull,fk=users.id,ondelete=cascade"`
+	Total  float64 `db:"total"`
+}
+
+func (o Order) StructName() string {
+	return "Order"
+}
+
+// Bookmark stores its tags in a PostgreSQL array column
+type Bookmark struct {
+	ID   int      `db:"id,pk"`
+	URL  string   `db:"url"`
+	Tags []string `db:"tags,array"`
+}
+
+func (b Bookmark) StructName() string {
+	return "Bookmark"
+}
+
+// Country is keyed on its code, found through a PKFunc
+type Country struct {
+	ID   int
+	Code string
+	Name string
+}
+
+func (c Country) StructName() string {
+	return "Country"
+}
+
+// LogLine has no primary key, SQLiteRowID keys it on the implicit rowid
+type LogLine struct {
+	RowID   int64  `db:"rowid,auto"`
+	Message string `db:"message"`
+}
+
+func (l LogLine) StructName() string {
+	return "LogLine"
+}
+
+// Memo is a soft deleted SQLite table keyed on its rowid
+type Memo struct {
+	RowID     int64      `db:"rowid,auto"`
+	Text      string     `db:"text"`
+	DeletedAt *time.Time `db:"deleted_at"`
+}
+
+func (m Memo) StructName() string {
+	return "Memo"
+}
+
+// Reader has nullable columns scanned into a pointer and a sql.Scanner field
+type Reader struct {
+	ID       int            `db:"id,pk"`
+	Name     string         `db:"name"`
+	Phone    *string        `db:"phone"`
+	Nickname sql.NullString `db:"nickname"`
+	Stage    Stage          `db:"stage"`
+}
+
+func (r Reader) StructName() string {
+	return "Reader"
+}
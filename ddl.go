@@ -0,0 +1,330 @@
+package structsql
+
+import (
+	"time"
+
+	"github.com/cdvelop/tinyreflect"
+	. "github.com/cdvelop/tinystring"
+)
+
+var timeType = tinyreflect.TypeOf(time.Time{})
+
+// CreateTable generates CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, email TEXT)
+// mapping every field type to the column type of the database type. Pointer fields
+// map to their element type, the pk and auto tag options add the key and identity clauses
+// and the notnull and default= options add NOT NULL and DEFAULT constraints.
+// Slices tagged with the array option map to PostgreSQL arrays, eg: TEXT[] for
+// []string, and to the json column type elsewhere.
+// The type= option sets the column type verbatim, eg: db:"name,type=VARCHAR(100)"
+// and the fk= option adds FOREIGN KEY (user_id) REFERENCES users (id), with ON DELETE
+// from the ondelete= option, eg: db:"user_id,fk=users.id,ondelete=cascade". SQLite
+// gets the REFERENCES clause inline in the column definition.
+func (s *Structsql) CreateTable(structTable any, sql *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "CREATE TABLE ")
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " (")
+
+	rowIDIndex := s.rowIDIndex(info) // implicit column, see SQLiteRowID
+	written := 0
+	for i := 0; i < numFields; i++ {
+		f := &info.fields[i]
+		if i == rowIDIndex {
+			continue
+		}
+		if written > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		written++
+		s.quote(f.Name, c)
+		c.WrString(BuffOut, " ")
+
+		colType := s.dbType.columnType(f.Typ)
+		if f.JSON {
+			colType = s.dbType.jsonType()
+		}
+		if f.Array {
+			if s.dbType == PostgreSQL {
+				colType = s.dbType.arrayType(f.Typ)
+			} else {
+				colType = s.dbType.jsonType()
+			}
+		}
+		if f.SQLType != "" {
+			colType = f.SQLType
+		}
+		if colType == "" {
+			return Err("unsupported column type", f.Name)
+		}
+		c.WrString(BuffOut, colType)
+
+		if i == info.pkIndex {
+			if f.Auto {
+				c.WrString(BuffOut, s.dbType.identity())
+			}
+			c.WrString(BuffOut, " PRIMARY KEY")
+		}
+		if f.NotNull {
+			c.WrString(BuffOut, " NOT NULL")
+		}
+		if f.Default != "" {
+			c.WrString(BuffOut, " DEFAULT ")
+			c.WrString(BuffOut, f.Default)
+		}
+		if f.RefTable != "" && s.dbType == SQLite {
+			s.writeReferences(c, f)
+		}
+	}
+
+	if s.dbType != SQLite {
+		for i := range info.fields {
+			f := &info.fields[i]
+			if f.RefTable == "" {
+				continue
+			}
+			c.WrString(BuffOut, ", FOREIGN KEY (")
+			s.quote(f.Name, c)
+			c.WrString(BuffOut, ")")
+			s.writeReferences(c, f)
+		}
+	}
+
+	c.WrString(BuffOut, ")")
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// writeReferences writes " REFERENCES users (id)" for the fk= option of f,
+// followed by the ON DELETE action when tagged with ondelete=
+func (s *Structsql) writeReferences(c *Conv, f *fieldInfo) {
+	c.WrString(BuffOut, " REFERENCES ")
+	s.quoteTable(f.RefTable, c)
+	c.WrString(BuffOut, " (")
+	s.quote(f.RefColumn, c)
+	c.WrString(BuffOut, ")")
+	if f.OnDelete != "" {
+		c.WrString(BuffOut, " ON DELETE ")
+		c.WrString(BuffOut, f.OnDelete)
+	}
+}
+
+// columnType returns the column type of the database type for the Go type typ,
+// or an empty string when typ has no column mapping.
+func (d dbType) columnType(typ *tinyreflect.Type) string {
+	if typ.Kind() == K.Pointer {
+		typ = typ.Elem()
+	}
+
+	if typ == timeType {
+		switch d {
+		case MySQL:
+			return "DATETIME"
+		case SQLServer:
+			return "DATETIME2"
+		}
+		return "TIMESTAMP"
+	}
+
+	switch typ.Kind() {
+	case K.Int8, K.Int16, K.Int32, K.Uint8, K.Uint16:
+		if d == SQLServer || d == MySQL {
+			return "INT"
+		}
+		return "INTEGER"
+	case K.Int, K.Int64, K.Uint, K.Uint32, K.Uint64:
+		if d == SQLite {
+			return "INTEGER" // SQLite integers are 64 bit
+		}
+		return "BIGINT"
+	case K.String:
+		switch d {
+		case MySQL:
+			return "VARCHAR(255)"
+		case SQLServer:
+			return "NVARCHAR(255)"
+		}
+		return "TEXT"
+	case K.Bool:
+		if d == SQLServer {
+			return "BIT"
+		}
+		return "BOOLEAN"
+	case K.Float32:
+		return "REAL"
+	case K.Float64:
+		switch d {
+		case PostgreSQL:
+			return "DOUBLE PRECISION"
+		case SQLite:
+			return "REAL"
+		case MySQL:
+			return "DOUBLE"
+		}
+		return "FLOAT"
+	case K.Slice:
+		if typ.Elem().Kind() != K.Uint8 {
+			return ""
+		}
+		switch d {
+		case PostgreSQL:
+			return "BYTEA"
+		case SQLServer:
+			return "VARBINARY(MAX)"
+		}
+		return "BLOB"
+	}
+	return ""
+}
+
+// jsonType returns the column type of fields tagged with the json option
+func (d dbType) jsonType() string {
+	switch d {
+	case PostgreSQL:
+		return "JSONB"
+	case MySQL:
+		return "JSON"
+	case SQLServer:
+		return "NVARCHAR(MAX)"
+	}
+	return "TEXT"
+}
+
+// identity returns the clause making a primary key generated by the database
+func (d dbType) identity() string {
+	switch d {
+	case PostgreSQL:
+		return " GENERATED BY DEFAULT AS IDENTITY"
+	case MySQL:
+		return " AUTO_INCREMENT"
+	case SQLServer:
+		return " IDENTITY(1,1)"
+	}
+	return "" // SQLite INTEGER PRIMARY KEY is already an alias of the rowid
+}
+
+// DropTable generates DROP TABLE IF EXISTS users
+func (s *Structsql) DropTable(structTable any, sql *string) error {
+	return s.tableStatement(structTable, "DROP TABLE IF EXISTS ", sql)
+}
+
+// Truncate generates TRUNCATE TABLE users, or DELETE FROM users for SQLite
+// which has no TRUNCATE statement.
+func (s *Structsql) Truncate(structTable any, sql *string) error {
+	if s.dbType == SQLite {
+		return s.tableStatement(structTable, "DELETE FROM ", sql)
+	}
+	return s.tableStatement(structTable, "TRUNCATE TABLE ", sql)
+}
+
+// tableStatement generates prefix followed by the table of structTable
+func (s *Structsql) tableStatement(structTable any, prefix string, sql *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	c.WrString(BuffOut, prefix)
+	s.quoteTable(tableStr, c)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateIndexes generates one CREATE INDEX idx_users_email ON users (email) per
+// column tagged with the index option, or CREATE UNIQUE INDEX for the unique option,
+// eg: db:"email,unique". stmts is reset and receives the statements in field order.
+func (s *Structsql) CreateIndexes(structTable any, stmts *[]string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	*stmts = (*stmts)[:0]
+	for i := range info.fields {
+		f := &info.fields[i]
+		if !f.Indexed {
+			continue
+		}
+
+		c.ResetBuffer(BuffOut)
+		if f.Unique {
+			c.WrString(BuffOut, "CREATE UNIQUE INDEX ")
+		} else {
+			c.WrString(BuffOut, "CREATE INDEX ")
+		}
+		s.quote("idx"+s.separator+tableStr+s.separator+f.Name, c)
+		c.WrString(BuffOut, " ON ")
+		s.quoteTable(tableStr, c)
+		c.WrString(BuffOut, " (")
+		s.quote(f.Name, c)
+		c.WrString(BuffOut, ")")
+
+		var stmt string
+		if err := s.setSQL(c, &stmt); err != nil {
+			return err
+		}
+		*stmts = append(*stmts, stmt)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestCreateTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs []any
+		table   any
+		wantSQL string
+	}{
+		{"sqlite", []any{structsql.SQLite}, User{},
+			"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, email TEXT)"},
+		{"postgres", nil, User{},
+			"CREATE TABLE users (id BIGINT PRIMARY KEY, name TEXT, email TEXT)"},
+		{"postgres time and pointer", nil, Account{},
+			"CREATE TABLE accounts (id BIGINT PRIMARY KEY, name TEXT, deleted_at TIMESTAMP)"},
+		{"postgres auto", nil, AutoUser{},
+			"CREATE TABLE users (id BIGINT GENERATED BY DEFAULT AS IDENTITY PRIMARY KEY, name TEXT, email TEXT)"},
+		{"sqlite time", []any{structsql.SQLite}, Post{},
+			"CREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT, created_at TIMESTAMP, updated_at TIMESTAMP)"},
+		{"constraints", nil, Subscription{},
+			"CREATE TABLE subscriptions (id BIGINT PRIMARY KEY, name TEXT NOT NULL, status TEXT NOT NULL DEFAULT 'active')"},
+		{"mysql constraints", []any{structsql.MySQL}, Subscription{},
+			"CREATE TABLE `subscriptions` (`id` BIGINT PRIMARY KEY, `name` VARCHAR(255) NOT NULL, `status` VARCHAR(255) NOT NULL DEFAULT 'active')"},
+		{"type override", nil, Price{},
+			"CREATE TABLE prices (id SMALLINT PRIMARY KEY, name VARCHAR(255), amount DECIMAL(10,2) NOT NULL)"},
+		{"foreign key", nil, Order{},
+			"CREATE TABLE orders (id BIGINT PRIMARY KEY, user_id BIGINT NOT NULL, total DOUBLE PRECISION, FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE)"},
+		{"array", nil, Bookmark{},
+			"CREATE TABLE bookmarks (id BIGINT PRIMARY KEY, url TEXT, tags TEXT[])"},
+		{"sqlite array", []any{structsql.SQLite}, Bookmark{},
+			"CREATE TABLE bookmarks (id INTEGER PRIMARY KEY, url TEXT, tags TEXT)"},
+		{"sqlite foreign key", []any{structsql.SQLite}, Order{},
+			"CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE, total REAL)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.configs...)
+			var gotSQL string
+			if err := s.CreateTable(tt.table, &gotSQL); err != nil {
+				t.Fatalf("CreateTable error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("CreateTable SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestDropTable(t *testing.T) {
+	wantSQL := "DROP TABLE IF EXISTS users"
+
+	s := structsql.New()
+	var gotSQL string
+	if err := s.DropTable(User{}, &gotSQL); err != nil {
+		t.Fatalf("DropTable error: %v", err)
+	}
+	if gotSQL != wantSQL {
+		t.Fatalf("DropTable SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs []any
+		wantSQL string
+	}{
+		{"postgres", nil, "TRUNCATE TABLE users"},
+		{"sqlite", []any{structsql.SQLite}, "DELETE FROM users"},
+		{"mysql", []any{structsql.MySQL}, "TRUNCATE TABLE `users`"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.configs...)
+			var gotSQL string
+			if err := s.Truncate(User{}, &gotSQL); err != nil {
+				t.Fatalf("Truncate error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("Truncate SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestCreateIndexes(t *testing.T) {
+	want := []string{
+		"CREATE UNIQUE INDEX idx_tenants_email ON tenants (email)",
+		"CREATE INDEX idx_tenants_tenant_id ON tenants (tenant_id)",
+	}
+
+	s := structsql.New()
+	var got []string
+	if err := s.CreateIndexes(Tenant{}, &got); err != nil {
+		t.Fatalf("CreateIndexes error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("CreateIndexes mismatch:\n got: %q\nwant: %q", got, want)
+	}
+}
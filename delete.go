@@ -6,14 +6,52 @@ import (
 )
 
 func (s *Structsql) Delete(structTable any, sql *string, values *[]any) error {
-	typ, err := s.validateStruct(structTable)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.delete(structTable, false, sql, values)
+}
+
+type sqliteReturning bool
+
+// SQLiteReturning passed to New declares the SQLite database is 3.35 or later,
+// which accepts RETURNING, so DeleteReturning supports SQLite.
+const SQLiteReturning sqliteReturning = true
+
+// DeleteReturning generates a DELETE followed by RETURNING with every column in
+// field order to capture the deleted row, eg:
+// DELETE FROM users WHERE id=$1 RETURNING id, name, email
+// Supported by PostgreSQL and, with SQLiteReturning, SQLite. Other database types
+// return an error.
+func (s *Structsql) DeleteReturning(structTable any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.deleteReturning() {
+		return Err("returning not supported by database type", string(s.dbType))
+	}
+
+	return s.delete(structTable, true, sql, values)
+}
+
+// deleteReturning reports whether the database accepts RETURNING on DELETE statements
+func (s *Structsql) deleteReturning() bool {
+	return s.dbType == PostgreSQL || (s.dbType == SQLite && s.sqliteReturning)
+}
+
+// delete implements Delete and DeleteReturning, the caller holds mu
+func (s *Structsql) delete(structTable any, returning bool, sql *string, values *[]any) error {
+	typ, err := s.validateStruct(&structTable)
 	if err != nil {
 		return err
 	}
 
 	v := structTable
 
-	c := s.setupConv()
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
 
 	var tableStr string
 	s.getTableName(typ, &tableStr)
@@ -23,30 +61,156 @@ func (s *Structsql) Delete(structTable any, sql *string, values *[]any) error {
 		return err
 	}
 
-	// Find ID field
-	idIndex, err := s.findIdField(tableStr, info.fields, true)
+	// Find ID field, or the rowid field with SQLiteRowID
+	idIndex, err := s.keyIndex(info)
 	if err != nil {
 		return err
 	}
 
 	// Build SQL
 	c.WrString(BuffOut, "DELETE FROM ")
-	c.WrString(BuffOut, tableStr)
-	c.WrString(BuffOut, " WHERE id=")
-	s.dbType.placeholder(1, c)
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " WHERE ")
+	s.quote(info.fields[idIndex].Name, c)
+	c.WrString(BuffOut, "=")
+	s.placeholder(1, c)
+	if returning {
+		s.writeReturning(c, info)
+	}
 
-	*sql = c.GetStringZeroCopy(BuffOut)
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
 
 	// Populate values
-	*values = (*values)[:0]
+	if err := s.ensureCapacity(values, 1); err != nil {
+		return err
+	}
 	val := tinyreflect.ValueOf(v)
-	fieldVal, err := val.Field(idIndex)
+	fieldVal, err := info.fields[idIndex].value(val)
 	if err != nil {
 		return err
 	}
 	var iface any
-	fieldVal.InterfaceZeroAlloc(&iface)
-	*values = append(*values, iface)
+	if err := bindValue(fieldVal, &iface); err != nil {
+		return err
+	}
+	s.appendArgs(values, iface)
+
+	return nil
+}
+
+// DeleteByIDs generates DELETE FROM users WHERE id IN ($1, $2, $3) with one
+// placeholder per id. The primary key column is detected from structTable.
+func (s *Structsql) DeleteByIDs(structTable any, ids []any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		return Err("no ids provided")
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	// Find ID field, or the rowid field with SQLiteRowID
+	idIndex, err := s.keyIndex(info)
+	if err != nil {
+		return err
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "DELETE FROM ")
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " WHERE ")
+	s.quote(info.fields[idIndex].Name, c)
+	c.WrString(BuffOut, " IN (")
+	for i := range ids {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.placeholder(i+1, c)
+	}
+	c.WrString(BuffOut, ")")
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	// Populate values
+	if err := s.ensureCapacity(values, len(ids)); err != nil {
+		return err
+	}
+	s.appendArgs(values, ids...)
+
+	return nil
+}
+
+type fullTableDelete bool
+
+// AllowFullTableDelete passed to New lets DeleteWhere generate DELETE FROM users
+// for a nil or empty Where. Without it the statement is refused with
+// ErrFullTableDelete, so a missing filter never wipes the whole table by accident.
+const AllowFullTableDelete fullTableDelete = true
+
+// DeleteWhere generates DELETE FROM users WHERE name=$1 AND age>$2 with the
+// condition values of where appended to values in clause order. At least one
+// condition is required unless AllowFullTableDelete is configured.
+func (s *Structsql) DeleteWhere(structTable any, where *Where, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	if (where == nil || len(where.conds) == 0) && !s.allowFullDelete {
+		return ErrFullTableDelete
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "DELETE FROM ")
+	s.quoteTable(tableStr, c)
+
+	if err := s.ensureCapacity(values, where.argCount()); err != nil {
+		return err
+	}
+	if _, err := s.writeWhere(c, info, where, 1, values); err != nil {
+		return err
+	}
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -9,7 +9,7 @@ import (
 
 func TestDelete(t *testing.T) {
 	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
-	wantSQL := "DELETE FROM user WHERE id=$1"
+	wantSQL := "DELETE FROM users WHERE id=$1"
 	wantArgs := []any{1}
 
 	s := structsql.New() // Default PostgreSQL
@@ -30,15 +30,229 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestDeleteReturning(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantArgs := []any{1}
+
+	tests := []struct {
+		name    string
+		configs []any
+		wantSQL string
+	}{
+		{"postgres", nil, "DELETE FROM users WHERE id=$1 RETURNING id, name, email"},
+		{"sqlite", []any{structsql.SQLite, structsql.SQLiteReturning}, "DELETE FROM users WHERE id=? RETURNING id, name, email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.configs...)
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := s.DeleteReturning(u, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("DeleteReturning error: %v", err)
+			}
+
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("DeleteReturning SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+
+			if !reflect.DeepEqual(gotArgs, wantArgs) {
+				t.Fatalf("DeleteReturning args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+			}
+		})
+	}
+
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+	if err := structsql.New(structsql.MySQL).DeleteReturning(u, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("DeleteReturning expected error for MySQL, got nil")
+	}
+
+	// SQLite before 3.35 rejects RETURNING, it must be enabled explicitly
+	if err := structsql.New(structsql.SQLite).DeleteReturning(u, &gotSQL, &gotArgs); err == nil {
+		t.Fatalf("DeleteReturning expected error for SQLite without SQLiteReturning, got SQL: %s", gotSQL)
+	}
+}
+
 func TestDeleteSQLite(t *testing.T) {
 	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
-	wantSQL := "DELETE FROM user WHERE id=?"
+	wantSQL := "DELETE FROM users WHERE id=?"
+	wantArgs := []any{1}
+
+	s := structsql.New(structsql.SQLite)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Delete(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Delete SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Delete args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestDeleteMySQL(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "DELETE FROM `users` WHERE `id`=?"
 	wantArgs := []any{1}
 
+	s := structsql.New(structsql.MySQL)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Delete(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Delete SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Delete args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestDeleteSQLServer(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "DELETE FROM [users] WHERE [id]=@p1"
+	wantArgs := []any{1}
+
+	s := structsql.New(structsql.SQLServer)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Delete(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Delete SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Delete args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestDeleteCustomPK(t *testing.T) {
+	p := Profile{UserID: 7, FirstName: "Alice"}
+	wantSQL := "DELETE FROM profiles WHERE user_id=$1"
+	wantArgs := []any{7}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Delete(p, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Delete SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Delete args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestDeleteConventionPK(t *testing.T) {
+	p := Product{IDProduct: 3, Name: "Book"}
+	wantSQL := "DELETE FROM products WHERE idproduct=$1"
+	wantArgs := []any{3}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Delete(p, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Delete SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Delete args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestDeleteByIDs(t *testing.T) {
+	wantSQL := "DELETE FROM users WHERE id IN ($1, $2, $3)"
+	wantArgs := []any{1, 2, 3}
+
+	s := structsql.New() // Default PostgreSQL
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.DeleteByIDs(User{}, []any{1, 2, 3}, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("DeleteByIDs error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("DeleteByIDs SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("DeleteByIDs args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestDeleteByIDsSQLite(t *testing.T) {
+	wantSQL := "DELETE FROM users WHERE id IN (?, ?)"
+	wantArgs := []any{1, 2}
+
 	s := structsql.New(structsql.SQLite)
 	var gotSQL string
 	gotArgs := make([]any, 0, 10)
 
+	err := s.DeleteByIDs(User{}, []any{1, 2}, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("DeleteByIDs error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("DeleteByIDs SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("DeleteByIDs args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestDeleteByIDsEmpty(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.DeleteByIDs(User{}, nil, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("DeleteByIDs expected error for empty ids, got nil")
+	}
+}
+
+func TestDeletePointer(t *testing.T) {
+	u := &User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "DELETE FROM users WHERE id=$1"
+	wantArgs := []any{1}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
 	err := s.Delete(u, &gotSQL, &gotArgs)
 	if err != nil {
 		t.Fatalf("Delete error: %v", err)
@@ -0,0 +1,73 @@
+package structsql
+
+// TableDescriptor reports how a struct is mapped, as returned by Describe
+type TableDescriptor struct {
+	Table      string   // resolved table name, eg: people for Person
+	Columns    []string // column names in field order, embedded struct fields included
+	PrimaryKey string   // primary key column, empty when the struct has none
+	Dialect    dbType   // database type the SQL is generated for
+}
+
+// Describe returns the table name, columns and primary key resolved for
+// structTable without generating SQL, to check db tags and naming decisions.
+func (s *Structsql) Describe(structTable any) (*TableDescriptor, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.setupConv(); err != nil {
+		return nil, err
+	}
+
+	d := &TableDescriptor{Dialect: s.dbType}
+	s.getTableName(typ, &d.Table)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Columns = info.columnNames()
+
+	if info.pkIndex != -1 {
+		d.PrimaryKey = info.fields[info.pkIndex].Name
+	}
+
+	return d, nil
+}
+
+// Columns returns the column names of structTable in field order, following the
+// db tags and leaving out excluded fields, eg: [id name email] for User.
+func (s *Structsql) Columns(structTable any) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.setupConv(); err != nil {
+		return nil, err
+	}
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	return info.columnNames(), nil
+}
+
+// columnNames returns a new slice with the column names of info in field order
+func (t *typeInfo) columnNames() []string {
+	names := make([]string, len(t.fields))
+	for i := range t.fields {
+		names[i] = t.fields[i].Name
+	}
+	return names
+}
@@ -0,0 +1,71 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestDescribe(t *testing.T) {
+	tests := []struct {
+		name    string
+		configs []any
+		row     any
+		want    structsql.TableDescriptor
+	}{
+		{"tagged pk", nil, Profile{},
+			structsql.TableDescriptor{Table: "profiles", Columns: []string{"user_id", "first_name", "created_at", "bio"},
+				PrimaryKey: "user_id", Dialect: structsql.PostgreSQL}},
+		{"english plural", []any{structsql.SQLite}, Person{},
+			structsql.TableDescriptor{Table: "people", Columns: []string{"id", "name"},
+				PrimaryKey: "id", Dialect: structsql.SQLite}},
+		{"singular", []any{structsql.Singular}, User{},
+			structsql.TableDescriptor{Table: "user", Columns: []string{"id", "name", "email"},
+				PrimaryKey: "id", Dialect: structsql.PostgreSQL}},
+		{"no primary key", nil, Note{},
+			structsql.TableDescriptor{Table: "notes", Columns: []string{"text"}, Dialect: structsql.PostgreSQL}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.configs...)
+			got, err := s.Describe(tt.row)
+			if err != nil {
+				t.Fatalf("Describe error: %v", err)
+			}
+			if !reflect.DeepEqual(*got, tt.want) {
+				t.Fatalf("Describe mismatch:\n got: %+v\nwant: %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumns(t *testing.T) {
+	tests := []struct {
+		name string
+		row  any
+		want []string
+	}{
+		{"tagged", User{}, []string{"id", "name", "email"}},
+		{"excluded field", Member{}, []string{"id", "email"}},
+		{"pointer", &Profile{}, []string{"user_id", "first_name", "created_at", "bio"}},
+	}
+
+	s := structsql.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Columns(tt.row)
+			if err != nil {
+				t.Fatalf("Columns error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Columns mismatch:\n got: %v\nwant: %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := s.Columns(nil); err == nil {
+		t.Fatal("Columns expected error for nil input, got nil")
+	}
+}
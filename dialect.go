@@ -0,0 +1,39 @@
+package structsql
+
+import (
+	"sync"
+
+	. "github.com/cdvelop/tinystring"
+)
+
+// dialect holds the SQL writers of a database type registered with RegisterDialect
+type dialect struct {
+	placeholder func(index int, conv *Conv)
+	quote       func(ident string, conv *Conv)
+}
+
+var (
+	dialectsMu sync.RWMutex
+	dialects   = map[dbType]dialect{}
+)
+
+// RegisterDialect adds a database type not built into the package, eg: DuckDB or
+// CockroachDB. ph writes the placeholder of the 1-based parameter index and quote
+// wraps a table or column name, a nil quote writes identifiers unquoted.
+// The returned value is passed to New like PostgreSQL or SQLite.
+// Registering an existing name replaces its writers.
+func RegisterDialect(name string, ph func(index int, conv *Conv), quote func(ident string, conv *Conv)) dbType {
+	d := dbType(name)
+	dialectsMu.Lock()
+	dialects[d] = dialect{placeholder: ph, quote: quote}
+	dialectsMu.Unlock()
+	return d
+}
+
+// lookupDialect returns the writers registered for d
+func lookupDialect(d dbType) (dialect, bool) {
+	dialectsMu.RLock()
+	reg, ok := dialects[d]
+	dialectsMu.RUnlock()
+	return reg, ok
+}
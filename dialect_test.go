@@ -0,0 +1,53 @@
+package structsql_test
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+	"github.com/cdvelop/tinystring"
+)
+
+func TestRegisterDialect(t *testing.T) {
+	colon := structsql.RegisterDialect("colon",
+		func(index int, conv *tinystring.Conv) {
+			conv.WrString(tinystring.BuffOut, ":"+strconv.Itoa(index))
+		},
+		func(ident string, conv *tinystring.Conv) {
+			conv.WrString(tinystring.BuffOut, "'"+ident+"'")
+		},
+	)
+
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES (:1, :2, :3)"
+	wantArgs := []any{1, "Alice", "alice@example.com"}
+
+	s := structsql.New(colon)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	// Identifiers go through the registered quote function when quoting is forced
+	s = structsql.New(colon, structsql.QuoteAlways)
+	if err := s.Insert(u, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	wantSQL = "INSERT INTO 'users' ('id', 'name', 'email') VALUES (:1, :2, :3)"
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
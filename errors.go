@@ -0,0 +1,37 @@
+package structsql
+
+import . "github.com/cdvelop/tinystring"
+
+// Sentinel errors returned by the verbs, compare them with errors.Is
+var (
+	ErrNilInput          error = Err("no struct table provided")
+	ErrNilPointer        error = Err("nil pointer provided")
+	ErrNotStruct         error = Err("input is not a struct")
+	ErrNotStructNamer    error = Err("struct does not implement StructNamer interface")
+	ErrNoFields          error = Err("struct has no fields")
+	ErrNoPrimaryKey      error = Err("struct must have a primary key field")
+	ErrUnknownColumn     error = Err("unknown column")
+	ErrClosed            error = Err("structsql closed")
+	ErrValuesCapacity    error = Err("values capacity too small")
+	ErrFullTableDelete   error = Err("delete without conditions requires AllowFullTableDelete")
+	ErrConversion        error = Err("sql conversion failed")
+	ErrUnknownConfig     error = Err("unknown config")
+	ErrPointerTableNamer error = Err("TableName must have a value receiver")
+	ErrNoJSONMarshal     error = Err("json field requires a JSONMarshal config")
+)
+
+// detailError adds a detail such as the offending column to a sentinel error
+// while errors.Is still matches the sentinel through Unwrap.
+type detailError struct {
+	kind error
+	msg  string
+}
+
+func (e *detailError) Error() string { return e.msg }
+
+func (e *detailError) Unwrap() error { return e.kind }
+
+// errDetail returns kind followed by detail, eg: "unknown column password"
+func errDetail(kind error, detail string) error {
+	return &detailError{kind: kind, msg: kind.Error() + " " + detail}
+}
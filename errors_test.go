@@ -0,0 +1,71 @@
+package structsql_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+	"github.com/cdvelop/tinystring"
+)
+
+func TestErrNoPrimaryKey(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Update(Note{Text: "hello"}, &gotSQL, &gotArgs)
+	if !errors.Is(err, structsql.ErrNoPrimaryKey) {
+		t.Fatalf("Update error mismatch:\n got: %v\nwant: %v", err, structsql.ErrNoPrimaryKey)
+	}
+	if err.Error() != "struct must have a primary key field" {
+		t.Fatalf("Update error message mismatch: %s", err.Error())
+	}
+}
+
+func TestErrSentinels(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	var nilUser *User
+	if err := s.Insert(nilUser, &gotSQL, &gotArgs); !errors.Is(err, structsql.ErrNilPointer) {
+		t.Fatalf("Insert nil pointer error mismatch: %v", err)
+	}
+	if err := s.Insert(nil, &gotSQL, &gotArgs); !errors.Is(err, structsql.ErrNilInput) {
+		t.Fatalf("Insert nil error mismatch: %v", err)
+	}
+	if err := s.Insert(42, &gotSQL, &gotArgs); !errors.Is(err, structsql.ErrNotStruct) {
+		t.Fatalf("Insert non struct error mismatch: %v", err)
+	}
+
+	err := s.CountBy(User{}, "password", &gotSQL, &gotArgs)
+	if !errors.Is(err, structsql.ErrUnknownColumn) {
+		t.Fatalf("CountBy error mismatch: %v", err)
+	}
+	if err.Error() != "unknown column password" {
+		t.Fatalf("CountBy error message mismatch: %s", err.Error())
+	}
+}
+
+func TestErrConversion(t *testing.T) {
+	// A dialect whose placeholder writer fails to convert its argument
+	broken := structsql.RegisterDialect("broken",
+		func(index int, conv *tinystring.Conv) {
+			conv.AnyToBuff(tinystring.BuffOut, struct{}{})
+		}, nil)
+
+	s := structsql.New(broken)
+	gotSQL := "unchanged"
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(User{ID: 1, Name: "Alice"}, &gotSQL, &gotArgs)
+	if !errors.Is(err, structsql.ErrConversion) {
+		t.Fatalf("Insert error mismatch:\n got: %v\nwant: %v", err, structsql.ErrConversion)
+	}
+	if err.Error() == structsql.ErrConversion.Error() {
+		t.Fatalf("Insert error lacks the buffered message: %s", err.Error())
+	}
+	if gotSQL != "unchanged" {
+		t.Fatalf("Insert published SQL after a conversion error: %s", gotSQL)
+	}
+}
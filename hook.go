@@ -0,0 +1,38 @@
+package structsql
+
+import . "github.com/cdvelop/tinystring"
+
+// SQLHook passed to New rewrites every generated statement before it is returned,
+// eg: to add optimizer hints or a comment routing reads to a replica:
+//
+//	hook := structsql.SQLHook(func(verb, sql string) string {
+//		if verb == "select" {
+//			return "/* replica */ " + sql
+//		}
+//		return sql
+//	})
+//
+// verb is the statement keyword as named by Annotate, eg: "insert", and sql
+// includes the Annotate comment. The hook runs while the Structsql is locked,
+// so it must not call back into it.
+type SQLHook func(verb, sql string) string
+
+// statementVerb returns the verb of the statement stmt, see statementVerbs
+func statementVerb(stmt string) string {
+	for _, sv := range statementVerbs {
+		if len(stmt) >= len(sv.keyword) && stmt[:len(sv.keyword)] == sv.keyword {
+			return sv.verb
+		}
+	}
+	return ""
+}
+
+// applyHook passes the statement in buff to the SQLHook and writes the result
+// into BuffWork, which it returns. The hook gets a copy since buff is reused.
+func (s *Structsql) applyHook(c *Conv, buff BuffDest) BuffDest {
+	stmt := c.GetStringZeroCopy(BuffOut)
+	rewritten := s.hook(statementVerb(stmt), c.GetString(buff))
+	c.ResetBuffer(BuffWork)
+	c.WrString(BuffWork, rewritten)
+	return BuffWork
+}
@@ -0,0 +1,41 @@
+package structsql_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestSQLHook(t *testing.T) {
+	var verbs []string
+	hook := structsql.SQLHook(func(verb, sql string) string {
+		verbs = append(verbs, verb)
+		if verb == "select" {
+			return sql + " /* replica */"
+		}
+		return sql
+	})
+
+	s := structsql.New(hook, structsql.Annotate)
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	var gotSQL string
+	args := make([]any, 0, 10)
+
+	if err := s.Insert(u, &gotSQL, &args); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	if want := "/* structsql:insert users */ INSERT INTO users (id, name, email) VALUES ($1, $2, $3)"; gotSQL != want {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+
+	if err := s.SelectAll(u, &gotSQL); err != nil {
+		t.Fatalf("SelectAll error: %v", err)
+	}
+	if want := "/* structsql:select users */ SELECT id, name, email FROM users /* replica */"; gotSQL != want {
+		t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+
+	if len(verbs) != 2 || verbs[0] != "insert" || verbs[1] != "select" {
+		t.Fatalf("hook verbs = %v, want [insert select]", verbs)
+	}
+}
@@ -1,20 +1,295 @@
 package structsql
 
 import (
+	"unsafe"
+
 	"github.com/cdvelop/tinyreflect"
 	. "github.com/cdvelop/tinystring"
 )
 
 func (s *Structsql) Insert(structTable any, sql *string, values *[]any) error {
-	typ, err := s.validateStruct(structTable)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.insert(structTable, sql, values)
+}
+
+// InsertBytes is Insert copying the statement into the caller's buffer sql, for
+// drivers and loggers taking []byte. Reusing sql across calls avoids the allocation
+// of converting the string returned by Insert:
+//
+//	buf := make([]byte, 0, 256)
+//	s.InsertBytes(u, &buf, &values)
+func (s *Structsql) InsertBytes(structTable any, sql *[]byte, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, cached, err := s.buildInsert(structTable, values)
+	if err != nil {
+		return err
+	}
+	if cached != "" {
+		*sql = append((*sql)[:0], cached...)
+		return nil
+	}
+
+	return s.setSQLBytes(c, sql)
+}
+
+// insert implements Insert, the caller holds mu
+func (s *Structsql) insert(structTable any, sql *string, values *[]any) error {
+	c, cached, err := s.buildInsert(structTable, values)
 	if err != nil {
 		return err
 	}
+	if cached != "" {
+		*sql = cached
+		return nil
+	}
+
+	return s.setSQL(c, sql)
+}
+
+// buildInsert writes the INSERT of structTable into BuffOut and populates values,
+// the caller publishes the statement with setSQL or setSQLBytes. When a previous
+// call already built the INSERT of the type it is returned in cached instead and
+// only values are populated.
+func (s *Structsql) buildInsert(structTable any, values *[]any) (c *Conv, cached string, err error) {
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return nil, "", err
+	}
 
 	// For now, handle only single struct (first one)
 	v := structTable
 
-	c := s.setupConv()
+	c, err = s.setupConv()
+	if err != nil {
+		return nil, "", err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return nil, "", err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return nil, "", ErrNoFields
+	}
+
+	if sql, ok := s.cachedBuilt(typ, "insert"); ok {
+		if err := s.ensureCapacity(values, info.insertColumns()); err != nil {
+			return nil, "", err
+		}
+		return c, sql, s.appendInsertValues(tinyreflect.ValueOf(v), info, values)
+	}
+
+	if err := s.writeInsert(c, "INSERT INTO ", tableStr, info, v, values); err != nil {
+		return nil, "", err
+	}
+
+	return c, "", nil
+}
+
+// InsertInto is Insert with an explicit table name, row may be any struct including
+// anonymous ones which have no StructName. table must be a plain identifier like
+// the table of InsertMap, it is qualified by the configured Schema, eg:
+//
+//	row := struct {
+//		Name  string `db:"name"`
+//		Email string `db:"email"`
+//	}{"Alice", "alice@example.com"}
+//	s.InsertInto("users", row, &sql, &values) // INSERT INTO users (name, email) VALUES ($1, $2)
+func (s *Structsql) InsertInto(table string, row any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if table == "" {
+		return Err("no table name provided")
+	}
+	if !isIdentifier(table) {
+		return Err("invalid table name", table)
+	}
+
+	typ, err := structType(&row)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	if len(info.fields) == 0 {
+		return ErrNoFields
+	}
+
+	if err := s.writeInsert(c, "INSERT INTO ", table, info, row, values); err != nil {
+		return err
+	}
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// InsertReturning generates an INSERT followed by RETURNING with the primary key column,
+// eg: INSERT INTO users (name, email) VALUES ($1, $2) RETURNING id
+// Only PostgreSQL supports it, other database types return an error.
+func (s *Structsql) InsertReturning(structTable any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dbType != PostgreSQL {
+		return Err("returning not supported by database type", string(s.dbType))
+	}
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	v := structTable
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+
+	idIndex, err := info.primaryKey()
+	if err != nil {
+		return err
+	}
+
+	if err := s.writeInsert(c, "INSERT INTO ", tableStr, info, v, values); err != nil {
+		return err
+	}
+
+	c.WrString(BuffOut, " RETURNING ")
+	s.quote(info.fields[idIndex].Name, c)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// writeInsert writes "INSERT INTO table (columns) VALUES (placeholders)" into BuffOut
+// and populates values in column order. Shared by every INSERT based verb, verb is
+// the statement start up to the table name, eg: "INSERT IGNORE INTO ".
+func (s *Structsql) writeInsert(c *Conv, verb, tableStr string, info *typeInfo, v any, values *[]any) error {
+	colCount := info.insertColumns()
+	if colCount == 0 {
+		return Err("no fields to insert")
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, verb)
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " (")
+
+	// Columns
+	written := 0
+	for i := range info.fields {
+		if info.fields[i].Auto {
+			continue
+		}
+		if written > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[i].Name, c)
+		written++
+	}
+
+	c.WrString(BuffOut, ") VALUES (")
+
+	// Placeholders
+	for i := 0; i < colCount; i++ {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.placeholder(i+1, c)
+	}
+
+	c.WrString(BuffOut, ")")
+
+	// Populate values slice (reuse caller's buffer)
+	if err := s.ensureCapacity(values, colCount); err != nil {
+		return err
+	}
+
+	return s.appendInsertValues(tinyreflect.ValueOf(v), info, values)
+}
+
+// InsertBatch generates a single multi-row INSERT for a slice of structs:
+// INSERT INTO users (id, name, email) VALUES ($1, $2, $3), ($4, $5, $6)
+// values receives every field of every row in row-major order.
+func (s *Structsql) InsertBatch(structSlice any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if structSlice == nil {
+		return ErrNilInput
+	}
+
+	sliceTyp := tinyreflect.TypeOf(structSlice)
+	if sliceTyp.Kind() != K.Slice {
+		return Err("input is not a slice")
+	}
+
+	rows := tinyreflect.ValueOf(structSlice)
+	numRows, err := rows.Len()
+	if err != nil {
+		return err
+	}
+	if numRows == 0 {
+		return Err("empty slice provided")
+	}
+
+	// Validate the element type through the first row
+	first, err := rows.Index(0)
+	if err != nil {
+		return err
+	}
+	row, err := first.Interface()
+	if err != nil {
+		return err
+	}
+	typ, err := s.validateStruct(&row)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
 
 	var tableStr string
 	s.getTableName(typ, &tableStr)
@@ -26,66 +301,340 @@ func (s *Structsql) Insert(structTable any, sql *string, values *[]any) error {
 
 	numFields := len(info.fields)
 	if numFields == 0 {
-		return Err("struct has no fields")
+		return ErrNoFields
 	}
 
-	// Collect columns for SQL building
-	var columns [32]string
-	var colCount int
+	// Build SQL
+	c.WrString(BuffOut, "INSERT INTO ")
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " (")
 
+	// Columns, auto generated ones are left to the database
+	colCount := 0
 	for i := 0; i < numFields; i++ {
-		fieldName := info.fields[i].Name
-		columns[colCount] = fieldName
+		if info.fields[i].Auto {
+			continue
+		}
+		if colCount > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[i].Name, c)
 		colCount++
 	}
 
+	if colCount == 0 {
+		return Err("no fields to insert")
+	}
+
+	c.WrString(BuffOut, ") VALUES ")
+
+	// One placeholder group per row, numbered continuously
+	index := 1
+	for r := 0; r < numRows; r++ {
+		if r > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		c.WrString(BuffOut, "(")
+		for i := 0; i < colCount; i++ {
+			if i > 0 {
+				c.WrString(BuffOut, ", ")
+			}
+			s.placeholder(index, c)
+			index++
+		}
+		c.WrString(BuffOut, ")")
+	}
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	// Populate values row by row
+	if err := s.ensureCapacity(values, numRows*colCount); err != nil {
+		return err
+	}
+
+	for r := 0; r < numRows; r++ {
+		rowVal, err := rows.Index(r)
+		if err != nil {
+			return err
+		}
+		if rowVal.Kind() == K.Pointer { // []*User
+			if rowVal, err = rowVal.Elem(); err != nil {
+				return err
+			}
+			if rowVal.Type() == nil {
+				return ErrNilPointer
+			}
+		}
+		// rows are read from the caller's slice, bind a copy
+		if rowVal, err = copyValue(rowVal); err != nil {
+			return err
+		}
+		if err := s.appendInsertValues(rowVal, info, values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// appendInsertValues appends the value of every inserted field of val to values
+// in field order, skipping auto generated fields like writeInsert does for columns.
+func (s *Structsql) appendInsertValues(val tinyreflect.Value, info *typeInfo, values *[]any) error {
+	base := structBase(val)
+	for i := 0; i < len(info.fields); i++ {
+		if info.fields[i].Auto {
+			continue
+		}
+		if err := s.appendInsertValue(val, base, &info.fields[i], values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// insertColumns counts the columns of an INSERT, auto fields are generated by the database
+func (t *typeInfo) insertColumns() int {
+	n := 0
+	for i := range t.fields {
+		if !t.fields[i].Auto {
+			n++
+		}
+	}
+	return n
+}
+
+// appendInsertValue appends the value inserted for the field f of the struct val
+// at base. Timestamp columns receive the current time instead of the struct value.
+func (s *Structsql) appendInsertValue(val tinyreflect.Value, base unsafe.Pointer, f *fieldInfo, values *[]any) error {
+	if f.AutoCreate || f.AutoUpdate {
+		*values = append(*values, s.timestampValue())
+		return nil
+	}
+
+	var iface any
+	if f.Array && s.dbType == PostgreSQL {
+		if err := bindArray(val, f, &iface); err != nil {
+			return err
+		}
+		*values = append(*values, iface)
+		return nil
+	}
+	if f.JSON || f.Array {
+		if err := s.bindJSON(val, f, &iface); err != nil {
+			return err
+		}
+		*values = append(*values, iface)
+		return nil
+	}
+
+	if f.direct(base, &iface) {
+		if err := bindValuer(&iface); err != nil {
+			return err
+		}
+		bindBasic(&iface)
+	} else {
+		fieldVal, err := f.value(val)
+		if err != nil {
+			return err
+		}
+		if err := bindValue(fieldVal, &iface); err != nil {
+			return err
+		}
+	}
+	s.bindTime(&iface)
+	s.bindBool(&iface)
+
+	*values = append(*values, iface) // Append to caller's buffer
+	return nil
+}
+
+// InsertNonZero generates an INSERT with only the fields holding a non zero value,
+// leaving the others to the column defaults of the database, eg:
+// User{Name: "Alice"} generates INSERT INTO users (name) VALUES ($1).
+// Zero means omit in this mode: a legitimate 0, "" or false is not inserted,
+// use Insert for it. Timestamp columns are always set.
+func (s *Structsql) InsertNonZero(structTable any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+
+	val := tinyreflect.ValueOf(structTable)
+
+	// Collect populated fields, on the stack up to 32 columns
+	var buf [32]int
+	insertFields := buf[:0]
+	for i := 0; i < numFields; i++ {
+		f := &info.fields[i]
+		if f.Auto {
+			continue
+		}
+		if !f.AutoCreate && !f.AutoUpdate {
+			fieldVal, err := f.value(val)
+			if err != nil {
+				return err
+			}
+			if isZero(fieldVal) {
+				continue
+			}
+		}
+		insertFields = append(insertFields, i)
+	}
+	colCount := len(insertFields)
+
+	if colCount == 0 {
+		return Err("no fields to insert")
+	}
+
 	// Build SQL
 	c.WrString(BuffOut, "INSERT INTO ")
-	c.WrString(BuffOut, tableStr)
+	s.quoteTable(tableStr, c)
 	c.WrString(BuffOut, " (")
 
-	// Columns
 	for i := 0; i < colCount; i++ {
 		if i > 0 {
 			c.WrString(BuffOut, ", ")
 		}
-		c.WrString(BuffOut, columns[i])
+		s.quote(info.fields[insertFields[i]].Name, c)
 	}
 
 	c.WrString(BuffOut, ") VALUES (")
 
-	// Placeholders
 	for i := 0; i < colCount; i++ {
 		if i > 0 {
 			c.WrString(BuffOut, ", ")
 		}
-		s.dbType.placeholder(i+1, c)
+		s.placeholder(i+1, c)
 	}
 
 	c.WrString(BuffOut, ")")
 
-	*sql = c.GetStringZeroCopy(BuffOut)
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
 
-	// Populate values slice (reuse caller's buffer)
-	*values = (*values)[:0] // Clear existing values
+	// Populate values
+	if err := s.ensureCapacity(values, colCount); err != nil {
+		return err
+	}
+	base := structBase(val)
+	for i := 0; i < colCount; i++ {
+		if err := s.appendInsertValue(val, base, &info.fields[insertFields[i]], values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InsertSelect copies rows between tables with matching columns, eg:
+//
+//	INSERT INTO users_archive (id, name, email) SELECT id, name, email FROM users WHERE id<$1
+//
+// dest and src must map to the same set of columns, src columns are selected in
+// the order of dest. The where conditions are checked against src and their
+// values appended to values.
+func (s *Structsql) InsertSelect(dest any, src any, where *Where, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	destTyp, err := s.validateStruct(&dest)
+	if err != nil {
+		return err
+	}
+
+	srcTyp, err := s.validateStruct(&src)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var destTable, srcTable string
+	s.getTableName(destTyp, &destTable)
+	s.getTableName(srcTyp, &srcTable)
+
+	destInfo, err := s.getTypeInfo(destTyp)
+	if err != nil {
+		return err
+	}
 
-	// Ensure sufficient capacity
-	if cap(*values) < numFields {
-		// This should rarely happen in benchmarks, but handle gracefully
-		*values = make([]any, 0, numFields)
+	srcInfo, err := s.getTypeInfo(srcTyp)
+	if err != nil {
+		return err
 	}
 
-	val := tinyreflect.ValueOf(v)
+	numFields := len(destInfo.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+	if len(srcInfo.fields) != numFields {
+		return Err("column sets don't match", destTable, srcTable)
+	}
 	for i := 0; i < numFields; i++ {
-		fieldVal, err := val.Field(i)
-		if err != nil {
-			return err
+		if srcInfo.columnIndex(destInfo.fields[i].Name) == -1 {
+			return errDetail(ErrUnknownColumn, destInfo.fields[i].Name)
 		}
+	}
 
-		var iface any
-		fieldVal.InterfaceZeroAlloc(&iface)
+	// Build SQL
+	c.WrString(BuffOut, "INSERT INTO ")
+	s.quoteTable(destTable, c)
+	c.WrString(BuffOut, " (")
+	for i := 0; i < numFields; i++ {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(destInfo.fields[i].Name, c)
+	}
 
-		*values = append(*values, iface) // Append to caller's buffer
+	c.WrString(BuffOut, ") SELECT ")
+	for i := 0; i < numFields; i++ {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(destInfo.fields[i].Name, c)
+	}
+
+	c.WrString(BuffOut, " FROM ")
+	s.quoteTable(srcTable, c)
+
+	if err := s.ensureCapacity(values, where.argCount()); err != nil {
+		return err
+	}
+	if _, err := s.writeWhere(c, srcInfo, where, 1, values); err != nil {
+		return err
+	}
+	s.writeNotDeleted(c, srcInfo, where != nil && len(where.conds) > 0)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
 	}
 
 	return nil
@@ -1,15 +1,19 @@
 package structsql_test
 
 import (
+	"errors"
 	"reflect"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/cdvelop/structsql"
 )
 
 func TestInsert(t *testing.T) {
 	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
-	wantSQL := "INSERT INTO user (id, name, email) VALUES ($1, $2, $3)"
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)"
 	wantArgs := []any{1, "Alice", "alice@example.com"}
 
 	s := structsql.New() // Default PostgreSQL
@@ -30,9 +34,259 @@ func TestInsert(t *testing.T) {
 	}
 }
 
+func TestInsertPlaceholderStart(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($3, $4, $5)"
+
+	s := structsql.New(structsql.PlaceholderStart(3))
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Insert(u, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	wantSQL = "UPDATE users SET name=$3, email=$4 WHERE id=$5"
+	if err := s.Update(u, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestInsertBytes(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)"
+	wantArgs := []any{1, "Alice", "alice@example.com"}
+
+	s := structsql.New()
+	gotSQL := make([]byte, 0, 64)
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.InsertBytes(u, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("InsertBytes error: %v", err)
+	}
+
+	if string(gotSQL) != wantSQL {
+		t.Fatalf("InsertBytes SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("InsertBytes args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	// The buffer is reused, not appended to
+	if err := s.InsertBytes(u, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("InsertBytes error: %v", err)
+	}
+	if string(gotSQL) != wantSQL {
+		t.Fatalf("second InsertBytes SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestInsertStrictCapacity(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+
+	s := structsql.New(structsql.StrictCapacity)
+	var gotSQL string
+
+	err := s.Insert(u, &gotSQL, new([]any))
+	if !errors.Is(err, structsql.ErrValuesCapacity) {
+		t.Fatalf("Insert error = %v, want ErrValuesCapacity", err)
+	}
+
+	// A large enough buffer is filled in place
+	gotArgs := make([]any, 0, 3)
+	buf := gotArgs[:cap(gotArgs)]
+	if err := s.Insert(u, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	if &gotArgs[0] != &buf[0] {
+		t.Fatal("Insert reallocated a buffer with enough capacity")
+	}
+
+	// The default mode grows short buffers
+	s = structsql.New()
+	if err := s.Insert(u, &gotSQL, new([]any)); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+}
+
+func TestStrictCapacityEveryMethod(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	where := structsql.NewWhere().Eq("name", "Alice").In("id", 1, 2)
+	data := map[string]any{"name": "Alice", "email": "alice@example.com"}
+
+	tests := []struct {
+		name string
+		call func(s *structsql.Structsql, values *[]any) error
+	}{
+		{"Insert", func(s *structsql.Structsql, v *[]any) error { return s.Insert(u, new(string), v) }},
+		{"InsertInto", func(s *structsql.Structsql, v *[]any) error { return s.InsertInto("people", u, new(string), v) }},
+		{"InsertReturning", func(s *structsql.Structsql, v *[]any) error { return s.InsertReturning(u, new(string), v) }},
+		{"InsertBatch", func(s *structsql.Structsql, v *[]any) error { return s.InsertBatch([]User{u, u}, new(string), v) }},
+		{"InsertNonZero", func(s *structsql.Structsql, v *[]any) error { return s.InsertNonZero(u, new(string), v) }},
+		{"InsertSelect", func(s *structsql.Structsql, v *[]any) error {
+			return s.InsertSelect(UserArchive{}, User{}, where, new(string), v)
+		}},
+		{"InsertNamed", func(s *structsql.Structsql, v *[]any) error { return s.InsertNamed(u, new(string), new([]string), v) }},
+		{"InsertMap", func(s *structsql.Structsql, v *[]any) error { return s.InsertMap("users", data, new(string), v) }},
+		{"UpdateMap", func(s *structsql.Structsql, v *[]any) error { return s.UpdateMap("users", 1, data, new(string), v) }},
+		{"UpsertMap", func(s *structsql.Structsql, v *[]any) error {
+			return s.UpsertMap("users", []string{"email"}, data, new(string), v)
+		}},
+		{"Upsert", func(s *structsql.Structsql, v *[]any) error { return s.Upsert(u, new(string), v) }},
+		{"InsertOrIgnore", func(s *structsql.Structsql, v *[]any) error { return s.InsertOrIgnore(u, new(string), v) }},
+		{"Update", func(s *structsql.Structsql, v *[]any) error { return s.Update(u, new(string), v) }},
+		{"UpdateNonZero", func(s *structsql.Structsql, v *[]any) error { return s.UpdateNonZero(u, new(string), v) }},
+		{"UpdateColumns", func(s *structsql.Structsql, v *[]any) error {
+			return s.UpdateColumns(Document{ID: 1, Title: "Draft", Version: 2}, []string{"title"}, new(string), v)
+		}},
+		{"UpdateBatch", func(s *structsql.Structsql, v *[]any) error { return s.UpdateBatch([]User{u, u}, new(string), v) }},
+		{"Delete", func(s *structsql.Structsql, v *[]any) error { return s.Delete(u, new(string), v) }},
+		{"DeleteByIDs", func(s *structsql.Structsql, v *[]any) error { return s.DeleteByIDs(u, []any{1, 2}, new(string), v) }},
+		{"DeleteWhere", func(s *structsql.Structsql, v *[]any) error { return s.DeleteWhere(u, where, new(string), v) }},
+		{"SoftDelete", func(s *structsql.Structsql, v *[]any) error { return s.SoftDelete(Account{ID: 1}, new(string), v) }},
+		{"Select", func(s *structsql.Structsql, v *[]any) error { return s.Select(u, new(string), v) }},
+		{"SelectColumns", func(s *structsql.Structsql, v *[]any) error {
+			return s.SelectColumns(u, []string{"name"}, new(string), v)
+		}},
+		{"SelectByColumn", func(s *structsql.Structsql, v *[]any) error {
+			return s.SelectByColumn(u, "email", "alice@example.com", new(string), v)
+		}},
+		{"SelectIn", func(s *structsql.Structsql, v *[]any) error { return s.SelectIn(u, "id", []any{1, 2}, new(string), v) }},
+		{"SelectAny", func(s *structsql.Structsql, v *[]any) error { return s.SelectAny(u, "id", []int{1, 2}, new(string), v) }},
+		{"SelectByExample", func(s *structsql.Structsql, v *[]any) error {
+			return s.SelectByExample(u, User{Name: "Alice"}, new(string), v)
+		}},
+		{"SelectWhere", func(s *structsql.Structsql, v *[]any) error { return s.SelectWhere(u, where, new(string), v) }},
+		{"SelectList", func(s *structsql.Structsql, v *[]any) error {
+			return s.SelectList(u, structsql.SelectOptions{Limit: 10, Offset: 20}, new(string), v)
+		}},
+		{"Exists", func(s *structsql.Structsql, v *[]any) error { return s.Exists(u, new(string), v) }},
+		{"Paginate", func(s *structsql.Structsql, v *[]any) error { return s.Paginate(u, 2, 10, new(string), new(string), v) }},
+		{"CountBy", func(s *structsql.Structsql, v *[]any) error { return s.CountBy(u, "email", new(string), v) }},
+		{"CountWhere", func(s *structsql.Structsql, v *[]any) error { return s.CountWhere(u, where, new(string), v) }},
+		{"CallProc", func(s *structsql.Structsql, v *[]any) error {
+			return s.CallProc("archive_user", []any{1, "Alice"}, new(string), v)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The default mode tells how many values the call needs
+			var want []any
+			if err := tt.call(structsql.New(), &want); err != nil {
+				t.Fatalf("%s error: %v", tt.name, err)
+			}
+
+			s := structsql.New(structsql.StrictCapacity)
+			short := make([]any, 0, len(want)-1)
+			if err := tt.call(s, &short); !errors.Is(err, structsql.ErrValuesCapacity) {
+				t.Fatalf("%s error = %v with capacity %d, want ErrValuesCapacity", tt.name, err, len(want)-1)
+			}
+
+			// An exact buffer is filled in place
+			gotArgs := make([]any, 0, len(want))
+			buf := gotArgs[:cap(gotArgs)]
+			if err := tt.call(s, &gotArgs); err != nil {
+				t.Fatalf("%s error: %v", tt.name, err)
+			}
+			if len(gotArgs) != len(want) || &gotArgs[0] != &buf[0] {
+				t.Fatalf("%s reallocated a buffer of capacity %d", tt.name, len(want))
+			}
+		})
+	}
+}
+
+func TestInsertNonZero(t *testing.T) {
+	tests := []struct {
+		name     string
+		row      any
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"partial", User{Name: "Alice"},
+			"INSERT INTO users (name) VALUES ($1)", []any{"Alice"}},
+		{"full", User{ID: 1, Name: "Alice", Email: "alice@example.com"},
+			"INSERT INTO users (id, name, email) VALUES ($1, $2, $3)", []any{1, "Alice", "alice@example.com"}},
+		{"timestamps", Post{Title: "Hello"},
+			"INSERT INTO posts (title, created_at, updated_at) VALUES ($1, $2, $3)", []any{"Hello", fixedNow, fixedNow}},
+	}
+
+	s := structsql.New(structsql.NowFunc(fixedClock))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+			if err := s.InsertNonZero(tt.row, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("InsertNonZero error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("InsertNonZero SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("InsertNonZero args mismatch:\n got: %v\nwant: %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+	if err := s.InsertNonZero(User{}, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("InsertNonZero expected error for zero struct, got nil")
+	}
+}
+
+func TestInsertInto(t *testing.T) {
+	row := struct {
+		Name  string `db:"name"`
+		Email string `db:"email"`
+	}{"Alice", "alice@example.com"}
+	wantSQL := "INSERT INTO users (name, email) VALUES ($1, $2)"
+	wantArgs := []any{"Alice", "alice@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.InsertInto("users", row, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("InsertInto error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("InsertInto SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("InsertInto args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	// The convenience API still requires a named struct
+	if err := s.Insert(row, &gotSQL, &gotArgs); !errors.Is(err, structsql.ErrNotStructNamer) {
+		t.Fatalf("Insert error = %v, want ErrNotStructNamer", err)
+	}
+
+	if err := s.InsertInto("", row, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("InsertInto expected error for empty table, got nil")
+	}
+
+	// The table is written into the SQL, only plain identifiers are accepted
+	if err := s.InsertInto("users; DROP TABLE x", row, &gotSQL, &gotArgs); err == nil {
+		t.Fatalf("InsertInto expected error for an invalid table name, got SQL: %s", gotSQL)
+	}
+}
+
 func TestInsertSQLite(t *testing.T) {
 	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
-	wantSQL := "INSERT INTO user (id, name, email) VALUES (?, ?, ?)"
+	wantSQL := "INSERT INTO users (id, name, email) VALUES (?, ?, ?)"
 	wantArgs := []any{1, "Alice", "alice@example.com"}
 
 	s := structsql.New(structsql.SQLite)
@@ -53,6 +307,606 @@ func TestInsertSQLite(t *testing.T) {
 	}
 }
 
+func TestInsertMySQL(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO `users` (`id`, `name`, `email`) VALUES (?, ?, ?)"
+	wantArgs := []any{1, "Alice", "alice@example.com"}
+
+	s := structsql.New(structsql.MySQL)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10) // Pre-allocate with capacity
+
+	err := s.Insert(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertSQLServer(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO [users] ([id], [name], [email]) VALUES (@p1, @p2, @p3)"
+	wantArgs := []any{1, "Alice", "alice@example.com"}
+
+	s := structsql.New(structsql.SQLServer)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertTagColumns(t *testing.T) {
+	p := Profile{UserID: 7, FirstName: "Alice", CreatedAt: "2025-01-01", Bio: "hi"}
+	wantSQL := "INSERT INTO profiles (user_id, first_name, created_at, bio) VALUES ($1, $2, $3, $4)"
+	wantArgs := []any{7, "Alice", "2025-01-01", "hi"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(p, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertAutoPK(t *testing.T) {
+	u := AutoUser{ID: 99, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (name, email) VALUES ($1, $2)"
+	wantArgs := []any{"Alice", "alice@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertBatchAutoPK(t *testing.T) {
+	users := []AutoUser{
+		{Name: "Alice", Email: "alice@example.com"},
+		{Name: "Bob", Email: "bob@example.com"},
+	}
+	wantSQL := "INSERT INTO users (name, email) VALUES ($1, $2), ($3, $4)"
+	wantArgs := []any{"Alice", "alice@example.com", "Bob", "bob@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.InsertBatch(users, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("InsertBatch error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("InsertBatch SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("InsertBatch args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertExcludedField(t *testing.T) {
+	m := Member{ID: 1, Password: "secret", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO members (id, email) VALUES ($1, $2)"
+	wantArgs := []any{1, "alice@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(m, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertPKLast(t *testing.T) {
+	tests := []struct {
+		name     string
+		verb     func(s *structsql.Structsql, sql *string, values *[]any) error
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"insert", func(s *structsql.Structsql, sql *string, values *[]any) error {
+			return s.Insert(Transfer{Memo: "rent", Amount: 500, ID: 9}, sql, values)
+		}, "INSERT INTO transfers (memo, amount, id) VALUES ($1, $2, $3)", []any{"rent", 500, 9}},
+		{"insert auto", func(s *structsql.Structsql, sql *string, values *[]any) error {
+			return s.Insert(Entry{Memo: "rent", Amount: 500}, sql, values)
+		}, "INSERT INTO entries (memo, amount) VALUES ($1, $2)", []any{"rent", 500}},
+		{"insert returning auto", func(s *structsql.Structsql, sql *string, values *[]any) error {
+			return s.InsertReturning(Entry{Memo: "rent", Amount: 500}, sql, values)
+		}, "INSERT INTO entries (memo, amount) VALUES ($1, $2) RETURNING id", []any{"rent", 500}},
+		{"batch auto", func(s *structsql.Structsql, sql *string, values *[]any) error {
+			return s.InsertBatch([]Entry{{Memo: "rent", Amount: 500}, {Memo: "food", Amount: 80}}, sql, values)
+		}, "INSERT INTO entries (memo, amount) VALUES ($1, $2), ($3, $4)", []any{"rent", 500, "food", 80}},
+	}
+
+	s := structsql.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+			if err := tt.verb(s, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("args mismatch:\n got: %v\nwant: %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestInsertSelect(t *testing.T) {
+	wantSQL := "INSERT INTO users_archive (id, name, email) SELECT id, name, email FROM users WHERE id<$1"
+	wantArgs := []any{100}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.InsertSelect(UserArchive{}, User{}, structsql.NewWhere().Lt("id", 100), &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("InsertSelect error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("InsertSelect SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("InsertSelect args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	if err := s.InsertSelect(UserArchive{}, Profile{}, nil, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("InsertSelect expected error for mismatched columns, got nil")
+	}
+	if err := s.InsertSelect(UserArchive{}, Customer{}, nil, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("InsertSelect expected error for missing columns, got nil")
+	}
+}
+
+func TestWideStruct(t *testing.T) {
+	w := Wide{ID: 1, C1: 1, C20: 20, C39: 39}
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Insert(w, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	if len(gotArgs) != 40 || !strings.HasSuffix(gotSQL, "c39) VALUES ($1, "+placeholders(2, 40)+")") {
+		t.Fatalf("Insert mismatch for 40 columns:\n got: %s\nargs: %d", gotSQL, len(gotArgs))
+	}
+	if gotArgs[39] != 39 {
+		t.Fatalf("Insert last value = %v, want 39", gotArgs[39])
+	}
+
+	if err := s.InsertNonZero(w, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("InsertNonZero error: %v", err)
+	}
+	wantSQL := "INSERT INTO wides (id, c1, c20, c39) VALUES ($1, $2, $3, $4)"
+	if gotSQL != wantSQL {
+		t.Fatalf("InsertNonZero SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	w = Wide{ID: 7}
+	for i, v := range []*int{&w.C1, &w.C2, &w.C3, &w.C4, &w.C5, &w.C6, &w.C7, &w.C8, &w.C9, &w.C10,
+		&w.C11, &w.C12, &w.C13, &w.C14, &w.C15, &w.C16, &w.C17, &w.C18, &w.C19, &w.C20,
+		&w.C21, &w.C22, &w.C23, &w.C24, &w.C25, &w.C26, &w.C27, &w.C28, &w.C29, &w.C30,
+		&w.C31, &w.C32, &w.C33, &w.C34, &w.C35, &w.C36, &w.C37, &w.C38, &w.C39} {
+		*v = i + 1
+	}
+	if err := s.Update(w, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+	if len(gotArgs) != 40 || !strings.HasSuffix(gotSQL, "c39=$39 WHERE id=$40") || gotArgs[39] != 7 {
+		t.Fatalf("Update mismatch for 39 SET columns:\n got: %s\nargs: %v", gotSQL, gotArgs)
+	}
+}
+
+// placeholders returns "$from, ..., $to"
+func placeholders(from, to int) string {
+	var b strings.Builder
+	for i := from; i <= to; i++ {
+		if i > from {
+			b.WriteString(", ")
+		}
+		b.WriteString("$" + strconv.Itoa(i))
+	}
+	return b.String()
+}
+
+func TestInsertReturning(t *testing.T) {
+	u := AutoUser{Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (name, email) VALUES ($1, $2) RETURNING id"
+	wantArgs := []any{"Alice", "alice@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.InsertReturning(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("InsertReturning error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("InsertReturning SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("InsertReturning args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertReturningSQLite(t *testing.T) {
+	u := AutoUser{Name: "Alice", Email: "alice@example.com"}
+
+	s := structsql.New(structsql.SQLite)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.InsertReturning(u, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("InsertReturning expected error for SQLite, got nil")
+	}
+}
+
+func TestInsertPointer(t *testing.T) {
+	u := &User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)"
+	wantArgs := []any{1, "Alice", "alice@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertNilPointer(t *testing.T) {
+	var u *User
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Insert(u, &gotSQL, &gotArgs); !errors.Is(err, structsql.ErrNilPointer) {
+		t.Fatalf("Insert error = %v, want ErrNilPointer", err)
+	}
+}
+
+// TestInsertArgsDontAlias changes the inserted structs after the call, the values
+// already returned must keep the state at the time of the call
+func TestInsertArgsDontAlias(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	gotArgs := make([]any, 0, 10)
+	if err := s.Insert(&u, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	u.ID, u.Name = 2, "MUTATED"
+	if want := []any{1, "Alice", "alice@example.com"}; !reflect.DeepEqual(gotArgs, want) {
+		t.Fatalf("Insert args changed with the struct:\n got: %v\nwant: %v", gotArgs, want)
+	}
+
+	phone := "555-0100"
+	c := Contact{ID: 1, Name: "Alice", Phone: &phone}
+	if err := s.Insert(c, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	phone = "MUTATED"
+	if want := []any{1, "Alice", "555-0100"}; !reflect.DeepEqual(gotArgs, want) {
+		t.Fatalf("Insert args changed with the pointed value:\n got: %v\nwant: %v", gotArgs, want)
+	}
+
+	users := []User{{ID: 1, Name: "Alice"}, {ID: 2, Name: "Bob"}}
+	if err := s.InsertBatch(users, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("InsertBatch error: %v", err)
+	}
+	users[0].Name = "MUTATED"
+	if want := []any{1, "Alice", "", 2, "Bob", ""}; !reflect.DeepEqual(gotArgs, want) {
+		t.Fatalf("InsertBatch args changed with the slice:\n got: %v\nwant: %v", gotArgs, want)
+	}
+}
+
+func TestInsertBatchPointers(t *testing.T) {
+	users := []*User{
+		{ID: 1, Name: "Alice", Email: "alice@example.com"},
+		{ID: 2, Name: "Bob", Email: "bob@example.com"},
+	}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3), ($4, $5, $6)"
+	wantArgs := []any{1, "Alice", "alice@example.com", 2, "Bob", "bob@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.InsertBatch(users, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("InsertBatch error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("InsertBatch SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("InsertBatch args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertNilPointerField(t *testing.T) {
+	c := Contact{ID: 1, Name: "Alice"}
+	wantSQL := "INSERT INTO contacts (id, name, phone) VALUES ($1, $2, $3)"
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(c, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if len(gotArgs) != 3 || gotArgs[2] != nil {
+		t.Fatalf("Insert expected untyped nil for nil pointer field, got: %#v", gotArgs)
+	}
+}
+
+func TestInsertPointerField(t *testing.T) {
+	phone := "555-0100"
+	c := Contact{ID: 1, Name: "Alice", Phone: &phone}
+	wantArgs := []any{1, "Alice", "555-0100"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(c, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertEmbedded(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	a := Article{Model: Model{ID: 1, CreatedAt: created}, Title: "Hello"}
+	wantSQL := "INSERT INTO articles (id, created_at, title) VALUES ($1, $2, $3)"
+	wantArgs := []any{1, created, "Hello"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(a, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertEmbeddedDuplicateColumn(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Insert(Clash{}, &gotSQL, &gotArgs); err == nil {
+		t.Fatalf("Insert expected duplicate column error, got SQL: %s", gotSQL)
+	}
+}
+
+func TestInsertBatch(t *testing.T) {
+	users := []User{
+		{ID: 1, Name: "Alice", Email: "alice@example.com"},
+		{ID: 2, Name: "Bob", Email: "bob@example.com"},
+	}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3), ($4, $5, $6)"
+	wantArgs := []any{1, "Alice", "alice@example.com", 2, "Bob", "bob@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.InsertBatch(users, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("InsertBatch error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("InsertBatch SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("InsertBatch args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertBatchSQLite(t *testing.T) {
+	users := []User{
+		{ID: 1, Name: "Alice", Email: "alice@example.com"},
+		{ID: 2, Name: "Bob", Email: "bob@example.com"},
+	}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES (?, ?, ?), (?, ?, ?)"
+
+	s := structsql.New(structsql.SQLite)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.InsertBatch(users, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("InsertBatch error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("InsertBatch SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestInsertBatchErrors(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.InsertBatch([]User{}, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("InsertBatch expected error for empty slice, got nil")
+	}
+
+	if err := s.InsertBatch(User{ID: 1}, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("InsertBatch expected error for non slice input, got nil")
+	}
+
+	if err := s.InsertBatch([]int{1, 2}, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("InsertBatch expected error for non struct elements, got nil")
+	}
+}
+
+func TestInsertCachedSQL(t *testing.T) {
+	pg := structsql.New()
+	lite := pg.WithDialect(structsql.SQLite)
+	defer lite.Close()
+
+	users := []User{
+		{ID: 1, Name: "Alice", Email: "alice@example.com"},
+		{ID: 2, Name: "Bob", Email: "bob@example.com"},
+	}
+
+	// The cache is shared with lite, each database type keeps its own statement
+	for _, u := range users {
+		var pgSQL, liteSQL string
+		args := make([]any, 0, 10)
+
+		if err := pg.Insert(u, &pgSQL, &args); err != nil {
+			t.Fatalf("Insert error: %v", err)
+		}
+		wantArgs := []any{u.ID, u.Name, u.Email}
+		if !reflect.DeepEqual(args, wantArgs) {
+			t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", args, wantArgs)
+		}
+		if err := lite.Insert(u, &liteSQL, &args); err != nil {
+			t.Fatalf("Insert error: %v", err)
+		}
+		if !reflect.DeepEqual(args, wantArgs) {
+			t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", args, wantArgs)
+		}
+
+		if want := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)"; pgSQL != want {
+			t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", pgSQL, want)
+		}
+		if want := "INSERT INTO users (id, name, email) VALUES (?, ?, ?)"; liteSQL != want {
+			t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", liteSQL, want)
+		}
+
+		buf := make([]byte, 0, 64)
+		if err := pg.InsertBytes(u, &buf, &args); err != nil {
+			t.Fatalf("InsertBytes error: %v", err)
+		}
+		if string(buf) != pgSQL {
+			t.Fatalf("InsertBytes SQL mismatch:\n got: %s\nwant: %s", buf, pgSQL)
+		}
+	}
+
+	// A hook runs on every call, its statements aren't cached
+	calls := 0
+	hooked := structsql.New(structsql.SQLHook(func(verb, sql string) string {
+		calls++
+		return sql
+	}))
+	for _, u := range users {
+		var gotSQL string
+		args := make([]any, 0, 10)
+		if err := hooked.Insert(u, &gotSQL, &args); err != nil {
+			t.Fatalf("Insert error: %v", err)
+		}
+	}
+	if calls != len(users) {
+		t.Fatalf("hook called %d times, want %d", calls, len(users))
+	}
+}
+
+// BenchmarkInsert repeats the INSERT of one type, after the first call the statement
+// comes from the built statement cache and only values are populated:
+//
+//	before the cache: ~300 ns/op  0 B/op  0 allocs/op
+//	after:            ~170 ns/op  0 B/op  0 allocs/op
 func BenchmarkInsert(b *testing.B) {
 	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
 	s := structsql.New()
@@ -65,6 +919,13 @@ func BenchmarkInsert(b *testing.B) {
 	}
 }
 
+// BenchmarkInsertWithArgs binds int and string fields. They are stored in values
+// pointing into the struct through the cached offsets, no field is reboxed. Boxing
+// them never allocated, so the binding has no allocation left to remove; medians
+// of -count 5, the current tree also caches the built SQL:
+//
+//	boxing every field: 593 ns/op   0 B/op   0 allocs/op
+//	cached offsets:     203 ns/op   0 B/op   0 allocs/op
 func BenchmarkInsertWithArgs(b *testing.B) {
 	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
 	s := structsql.New()
@@ -76,3 +937,101 @@ func BenchmarkInsertWithArgs(b *testing.B) {
 		_ = s.Insert(u, &sql, &args)
 	}
 }
+
+// sqlBytesSink keeps the converted statement alive so the conversion isn't optimized away
+var sqlBytesSink []byte
+
+// BenchmarkInsertStringToBytes converts the SQL of Insert for a []byte consumer:
+//
+//	~380 ns/op  64 B/op  1 allocs/op
+func BenchmarkInsertStringToBytes(b *testing.B) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	s := structsql.New()
+	var sql string
+	args := make([]any, 0, 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		args = args[:0]
+		_ = s.Insert(u, &sql, &args)
+		sqlBytesSink = []byte(sql)
+	}
+}
+
+// BenchmarkInsertBytes writes the SQL straight into a reused buffer:
+//
+//	~290 ns/op   0 B/op  0 allocs/op
+func BenchmarkInsertBytes(b *testing.B) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	s := structsql.New()
+	sql := make([]byte, 0, 128)
+	args := make([]any, 0, 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		args = args[:0]
+		_ = s.InsertBytes(u, &sql, &args)
+	}
+}
+
+func BenchmarkInsertCachedTableNames(b *testing.B) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	p := Profile{UserID: 1, FirstName: "Alice"}
+	c := Category{ID: 1, Name: "Books"}
+	s := structsql.New()
+	var sql string
+	args := make([]any, 0, 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		args = args[:0]
+		_ = s.Insert(u, &sql, &args)
+		args = args[:0]
+		_ = s.Insert(p, &sql, &args)
+		args = args[:0]
+		_ = s.Insert(c, &sql, &args)
+	}
+}
+
+func BenchmarkInsertBatch1000(b *testing.B) {
+	users := make([]User, 1000)
+	for i := range users {
+		users[i] = User{ID: i + 1, Name: "Alice", Email: "alice@example.com"}
+	}
+	s := structsql.New()
+	var sql string
+	args := make([]any, 0, 3000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		args = args[:0]
+		_ = s.InsertBatch(users, &sql, &args)
+	}
+}
+
+// BenchmarkInsertManyTypes inserts across 50 distinct struct types,
+// measuring the cache lookup cost when an application has many models.
+// Their byte array field is bound as a []byte viewing the array in the private
+// copy of the struct, boxing the slice header is the remaining allocation;
+// medians of -count 5 on the same tree:
+//
+//	copying the array: 656 ns/op  54 B/op   2 allocs/op
+//	viewing the array: 477 ns/op  24 B/op   1 allocs/op
+func BenchmarkInsertManyTypes(b *testing.B) {
+	rows := []any{
+		Item[[1]byte]{}, Item[[2]byte]{}, Item[[3]byte]{}, Item[[4]byte]{}, Item[[5]byte]{},
+		Item[[6]byte]{}, Item[[7]byte]{}, Item[[8]byte]{}, Item[[9]byte]{}, Item[[10]byte]{},
+		Item[[11]byte]{}, Item[[12]byte]{}, Item[[13]byte]{}, Item[[14]byte]{}, Item[[15]byte]{},
+		Item[[16]byte]{}, Item[[17]byte]{}, Item[[18]byte]{}, Item[[19]byte]{}, Item[[20]byte]{},
+		Item[[21]byte]{}, Item[[22]byte]{}, Item[[23]byte]{}, Item[[24]byte]{}, Item[[25]byte]{},
+		Item[[26]byte]{}, Item[[27]byte]{}, Item[[28]byte]{}, Item[[29]byte]{}, Item[[30]byte]{},
+		Item[[31]byte]{}, Item[[32]byte]{}, Item[[33]byte]{}, Item[[34]byte]{}, Item[[35]byte]{},
+		Item[[36]byte]{}, Item[[37]byte]{}, Item[[38]byte]{}, Item[[39]byte]{}, Item[[40]byte]{},
+		Item[[41]byte]{}, Item[[42]byte]{}, Item[[43]byte]{}, Item[[44]byte]{}, Item[[45]byte]{},
+		Item[[46]byte]{}, Item[[47]byte]{}, Item[[48]byte]{}, Item[[49]byte]{}, Item[[50]byte]{},
+	}
+	s := structsql.New()
+	var sql string
+	args := make([]any, 0, 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		args = args[:0]
+		_ = s.Insert(rows[i%len(rows)], &sql, &args)
+	}
+}
@@ -0,0 +1,126 @@
+package structsql
+
+import . "github.com/cdvelop/tinystring"
+
+// JoinKind selects the join type generated by Join
+type JoinKind string
+
+const (
+	InnerJoin JoinKind = "INNER JOIN" // default, rows matching on both sides
+	LeftJoin  JoinKind = "LEFT JOIN"  // every left row, right columns NULL without a match
+)
+
+// JoinOn relates the two tables of Join: each side has an alias qualifying its
+// columns and the column compared in the ON clause, eg: u.id = o.user_id
+type JoinOn struct {
+	LeftAlias   string
+	LeftColumn  string
+	RightAlias  string
+	RightColumn string
+	Kind        JoinKind // empty for InnerJoin
+}
+
+// Join generates SELECT u.id, u.name, o.id, o.user_id FROM users u INNER JOIN orders o ON u.id = o.user_id
+// projecting every column of left followed by every column of right. Aliases must be
+// distinct plain identifiers and the ON columns must exist in their struct.
+func (s *Structsql) Join(left any, right any, on JoinOn, sql *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kind := on.Kind
+	switch kind {
+	case "":
+		kind = InnerJoin
+	case InnerJoin, LeftJoin:
+	default:
+		return Err("invalid join kind", string(kind))
+	}
+
+	if !isIdentifier(on.LeftAlias) {
+		return Err("invalid alias", on.LeftAlias)
+	}
+	if !isIdentifier(on.RightAlias) || on.RightAlias == on.LeftAlias {
+		return Err("invalid alias", on.RightAlias)
+	}
+
+	leftTyp, err := s.validateStruct(&left)
+	if err != nil {
+		return err
+	}
+
+	rightTyp, err := s.validateStruct(&right)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var leftTable, rightTable string
+	s.getTableName(leftTyp, &leftTable)
+	s.getTableName(rightTyp, &rightTable)
+
+	leftInfo, err := s.getTypeInfo(leftTyp)
+	if err != nil {
+		return err
+	}
+
+	rightInfo, err := s.getTypeInfo(rightTyp)
+	if err != nil {
+		return err
+	}
+
+	if len(leftInfo.fields) == 0 || len(rightInfo.fields) == 0 {
+		return ErrNoFields
+	}
+
+	leftIndex := leftInfo.columnIndex(on.LeftColumn)
+	if leftIndex == -1 {
+		return errDetail(ErrUnknownColumn, on.LeftColumn)
+	}
+
+	rightIndex := rightInfo.columnIndex(on.RightColumn)
+	if rightIndex == -1 {
+		return errDetail(ErrUnknownColumn, on.RightColumn)
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT ")
+	s.writeAliasedColumns(c, leftInfo, on.LeftAlias)
+	c.WrString(BuffOut, ", ")
+	s.writeAliasedColumns(c, rightInfo, on.RightAlias)
+
+	c.WrString(BuffOut, " FROM ")
+	s.quoteTable(leftTable, c)
+	c.WrString(BuffOut, " ")
+	c.WrString(BuffOut, on.LeftAlias)
+
+	c.WrString(BuffOut, " ")
+	c.WrString(BuffOut, string(kind))
+	c.WrString(BuffOut, " ")
+	s.quoteTable(rightTable, c)
+	c.WrString(BuffOut, " ")
+	c.WrString(BuffOut, on.RightAlias)
+
+	c.WrString(BuffOut, " ON ")
+	c.WrString(BuffOut, on.LeftAlias)
+	c.WrString(BuffOut, ".")
+	s.quote(leftInfo.fields[leftIndex].Name, c)
+	c.WrString(BuffOut, " = ")
+	c.WrString(BuffOut, on.RightAlias)
+	c.WrString(BuffOut, ".")
+	s.quote(rightInfo.fields[rightIndex].Name, c)
+
+	// The right side filter belongs to ON so a LEFT JOIN keeps left rows
+	// whose only matches are soft deleted.
+	s.writeNotDeletedAs(c, rightInfo, on.RightAlias, true)
+	s.writeNotDeletedAs(c, leftInfo, on.LeftAlias, false)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package structsql_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestJoin(t *testing.T) {
+	tests := []struct {
+		name    string
+		on      structsql.JoinOn
+		wantSQL string
+	}{
+		{
+			name: "inner",
+			on:   structsql.JoinOn{LeftAlias: "u", LeftColumn: "id", RightAlias: "p", RightColumn: "user_id"},
+			wantSQL: "SELECT u.id, u.name, u.email, p.user_id, p.first_name, p.created_at, p.bio " +
+				"FROM users u INNER JOIN profiles p ON u.id = p.user_id",
+		},
+		{
+			name: "left",
+			on:   structsql.JoinOn{LeftAlias: "u", LeftColumn: "id", RightAlias: "p", RightColumn: "user_id", Kind: structsql.LeftJoin},
+			wantSQL: "SELECT u.id, u.name, u.email, p.user_id, p.first_name, p.created_at, p.bio " +
+				"FROM users u LEFT JOIN profiles p ON u.id = p.user_id",
+		},
+	}
+
+	s := structsql.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSQL string
+			if err := s.Join(User{}, Profile{}, tt.on, &gotSQL); err != nil {
+				t.Fatalf("Join error: %v", err)
+			}
+
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("Join SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestJoinErrors(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+
+	on := structsql.JoinOn{LeftAlias: "u", LeftColumn: "id", RightAlias: "p", RightColumn: "owner_id"}
+	if err := s.Join(User{}, Profile{}, on, &gotSQL); !errors.Is(err, structsql.ErrUnknownColumn) {
+		t.Fatalf("Join error = %v, want ErrUnknownColumn", err)
+	}
+
+	on = structsql.JoinOn{LeftAlias: "u", LeftColumn: "id", RightAlias: "u", RightColumn: "user_id"}
+	if err := s.Join(User{}, Profile{}, on, &gotSQL); err == nil {
+		t.Fatal("Join expected error for duplicated alias")
+	}
+}
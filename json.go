@@ -0,0 +1,62 @@
+package structsql
+
+import (
+	"unsafe"
+
+	"github.com/cdvelop/tinyreflect"
+)
+
+// JSONMarshal passed to New encodes the fields tagged with the json option, eg:
+// structsql.New(structsql.JSONMarshal(json.Marshal)). It is injected so structsql
+// doesn't depend on encoding/json, binding a json field without it returns
+// ErrNoJSONMarshal.
+type JSONMarshal func(v any) ([]byte, error)
+
+// bindJSON binds the value of f, a field tagged with the json option, as its JSON
+// encoding, eg: Meta map[string]any `db:"meta,json"` binds `{"plan":"pro"}`.
+// A nil pointer, map or slice, which encodes as null, is bound as NULL.
+func (s *Structsql) bindJSON(row tinyreflect.Value, f *fieldInfo, iface *any) error {
+	if s.jsonMarshal == nil {
+		return errDetail(ErrNoJSONMarshal, f.Name)
+	}
+	v, err := fieldInterface(row, f)
+	if err != nil {
+		return err
+	}
+	data, err := s.jsonMarshal(v)
+	if err != nil {
+		return err
+	}
+	if string(data) == "null" {
+		*iface = nil
+		return nil
+	}
+	*iface = string(data)
+	return nil
+}
+
+// fieldInterface returns a copy of the field f of the struct value row as an
+// interface, built from the cached offset and type for the field kinds, like maps
+// and pointers, that tinyreflect Interface doesn't unwrap.
+func fieldInterface(row tinyreflect.Value, f *fieldInfo) (any, error) {
+	v, err := row.Interface()
+	if err != nil {
+		return nil, err
+	}
+	re := (*tinyreflect.EmptyInterface)(unsafe.Pointer(&v))
+
+	var field any
+	fe := (*tinyreflect.EmptyInterface)(unsafe.Pointer(&field))
+	fe.Type = f.Typ
+	switch {
+	case !re.Type.IfaceIndir():
+		// A struct of a single pointer shaped field is held as that field
+		fe.Data = re.Data
+	case f.Typ.IfaceIndir():
+		fe.Data = unsafe.Add(re.Data, f.Offset)
+		copyInterface(&field)
+	default:
+		fe.Data = *(*unsafe.Pointer)(unsafe.Add(re.Data, f.Offset))
+	}
+	return field, nil
+}
@@ -0,0 +1,100 @@
+package structsql_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestInsertJSON(t *testing.T) {
+	st := Setting{ID: 1, Meta: map[string]any{"plan": "pro"}}
+	wantSQL := "INSERT INTO settings (id, meta) VALUES ($1, $2)"
+	wantArgs := []any{1, `{"plan":"pro"}`}
+
+	s := structsql.New(structsql.JSONMarshal(json.Marshal))
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(st, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	// A nil map is bound as NULL
+	if err := s.Insert(Setting{ID: 2}, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	wantArgs = []any{2, nil}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdateJSON(t *testing.T) {
+	st := Setting{ID: 1, Meta: map[string]any{"plan": "pro"}}
+	wantSQL := "UPDATE settings SET meta=$1 WHERE id=$2"
+	wantArgs := []any{`{"plan":"pro"}`, 1}
+
+	s := structsql.New(structsql.JSONMarshal(json.Marshal))
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Update(st, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestJSONWithoutMarshal(t *testing.T) {
+	st := Setting{ID: 1, Meta: map[string]any{"plan": "pro"}}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(st, &gotSQL, &gotArgs)
+	if !errors.Is(err, structsql.ErrNoJSONMarshal) {
+		t.Fatalf("Insert error = %v, want ErrNoJSONMarshal", err)
+	}
+}
+
+func TestCreateTableJSON(t *testing.T) {
+	tests := []struct {
+		db      any
+		wantSQL string
+	}{
+		{structsql.PostgreSQL, "CREATE TABLE settings (id BIGINT PRIMARY KEY, meta JSONB)"},
+		{structsql.SQLite, "CREATE TABLE settings (id INTEGER PRIMARY KEY, meta TEXT)"},
+		{structsql.MySQL, "CREATE TABLE `settings` (`id` BIGINT PRIMARY KEY, `meta` JSON)"},
+	}
+
+	for _, tt := range tests {
+		s := structsql.New(tt.db)
+		var gotSQL string
+		if err := s.CreateTable(Setting{}, &gotSQL); err != nil {
+			t.Fatalf("CreateTable error: %v", err)
+		}
+		if gotSQL != tt.wantSQL {
+			t.Fatalf("CreateTable SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+		}
+	}
+}
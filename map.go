@@ -0,0 +1,285 @@
+package structsql
+
+import . "github.com/cdvelop/tinystring"
+
+// InsertMap generates INSERT INTO table (a, b) VALUES ($1, $2) from column/value
+// pairs without a struct. Columns are sorted so the SQL is stable across calls
+// regardless of map iteration order, values follow the same order.
+func (s *Structsql) InsertMap(table string, data map[string]any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(data) == 0 {
+		return Err("no fields to insert")
+	}
+
+	columns, err := sortedColumns(table, data)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "INSERT INTO ")
+	s.quoteTable(table, c)
+	c.WrString(BuffOut, " (")
+
+	// Columns
+	for i, column := range columns {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(column, c)
+	}
+
+	c.WrString(BuffOut, ") VALUES (")
+
+	// Placeholders
+	for i := range columns {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.placeholder(i+1, c)
+	}
+
+	c.WrString(BuffOut, ")")
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	// Populate values in column order
+	if err := s.ensureCapacity(values, len(columns)); err != nil {
+		return err
+	}
+	for _, column := range columns {
+		s.appendArgs(values, data[column])
+	}
+
+	return nil
+}
+
+// sortedColumns validates table and the keys of data as identifiers and returns
+// the keys in ascending order. Maps are often built from request payloads, so
+// names are restricted to letters, digits and underscores.
+func sortedColumns(table string, data map[string]any) ([]string, error) {
+	if !isIdentifier(table) {
+		return nil, Err("invalid table name", table)
+	}
+
+	columns := make([]string, 0, len(data))
+	for column := range data {
+		if !isIdentifier(column) {
+			return nil, errDetail(ErrUnknownColumn, column)
+		}
+		// insertion sort, column counts are small
+		i := len(columns)
+		columns = append(columns, column)
+		for i > 0 && columns[i-1] > column {
+			columns[i] = columns[i-1]
+			i--
+		}
+		columns[i] = column
+	}
+	return columns, nil
+}
+
+// isIdentifier reports whether name is a plain SQL identifier: letters, digits
+// and underscores, not starting with a digit.
+func isIdentifier(name string) bool {
+	if name == "" || isDigit(name[0]) {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		b := name[i]
+		if !isLower(b) && !isUpper(b) && !isDigit(b) && b != '_' {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateMap generates UPDATE table SET a=$1, b=$2 WHERE id=$3 from column/value
+// pairs without a struct. Columns are sorted like InsertMap and id is appended last.
+func (s *Structsql) UpdateMap(table string, id any, data map[string]any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(data) == 0 {
+		return Err("no fields to update")
+	}
+
+	columns, err := sortedColumns(table, data)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "UPDATE ")
+	s.quoteTable(table, c)
+	c.WrString(BuffOut, " SET ")
+
+	// SET clauses
+	for i, column := range columns {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(column, c)
+		c.WrString(BuffOut, "=")
+		s.placeholder(i+1, c)
+	}
+
+	// WHERE
+	c.WrString(BuffOut, " WHERE ")
+	s.quote("id", c)
+	c.WrString(BuffOut, "=")
+	s.placeholder(len(columns)+1, c)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	// Populate values in column order, id at the end
+	if err := s.ensureCapacity(values, len(columns)+1); err != nil {
+		return err
+	}
+	for _, column := range columns {
+		s.appendArgs(values, data[column])
+	}
+	s.appendArgs(values, id)
+
+	return nil
+}
+
+// UpsertMap generates an InsertMap that updates the other columns when a row with
+// the same conflictCols exists:
+//
+//	PostgreSQL: INSERT ... ON CONFLICT (a, b) DO UPDATE SET c=$4
+//	SQLite:     INSERT ... ON CONFLICT(a, b) DO UPDATE SET c=?
+//	MySQL:      INSERT ... ON DUPLICATE KEY UPDATE c=?
+//
+// MySQL has no conflict target and relies on the unique keys of the table.
+// Columns are sorted like InsertMap and the update values follow the insert values.
+func (s *Structsql) UpsertMap(table string, conflictCols []string, data map[string]any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(data) == 0 {
+		return Err("no fields to insert")
+	}
+	if len(conflictCols) == 0 {
+		return Err("no conflict columns provided")
+	}
+
+	columns, err := sortedColumns(table, data)
+	if err != nil {
+		return err
+	}
+
+	for _, column := range conflictCols {
+		if _, ok := data[column]; !ok {
+			return errDetail(ErrUnknownColumn, column)
+		}
+	}
+	setCount := 0
+	for _, column := range columns {
+		if !containsColumn(conflictCols, column) {
+			setCount++
+		}
+	}
+	if setCount == 0 {
+		return Err("no fields to update")
+	}
+
+	switch s.dbType {
+	case PostgreSQL, SQLite, MySQL:
+	default:
+		return Err("upsert not supported by database type", string(s.dbType))
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "INSERT INTO ")
+	s.quoteTable(table, c)
+	c.WrString(BuffOut, " (")
+
+	// Columns
+	for i, column := range columns {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(column, c)
+	}
+
+	c.WrString(BuffOut, ") VALUES (")
+
+	// Placeholders
+	for i := range columns {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.placeholder(i+1, c)
+	}
+
+	c.WrString(BuffOut, ")")
+
+	// Conflict clause
+	switch s.dbType {
+	case PostgreSQL:
+		c.WrString(BuffOut, " ON CONFLICT (")
+	case SQLite:
+		c.WrString(BuffOut, " ON CONFLICT(")
+	case MySQL:
+		c.WrString(BuffOut, " ON DUPLICATE KEY UPDATE ")
+	}
+	if s.dbType != MySQL {
+		for i, column := range conflictCols {
+			if i > 0 {
+				c.WrString(BuffOut, ", ")
+			}
+			s.quote(column, c)
+		}
+		c.WrString(BuffOut, ") DO UPDATE SET ")
+	}
+
+	// SET clauses, every column outside the conflict target
+	if err := s.ensureCapacity(values, len(columns)+setCount); err != nil {
+		return err
+	}
+	for _, column := range columns {
+		s.appendArgs(values, data[column])
+	}
+	placeholder := len(columns)
+	for _, column := range columns {
+		if containsColumn(conflictCols, column) {
+			continue
+		}
+		if placeholder > len(columns) {
+			c.WrString(BuffOut, ", ")
+		}
+		placeholder++
+		s.quote(column, c)
+		c.WrString(BuffOut, "=")
+		s.placeholder(placeholder, c)
+		s.appendArgs(values, data[column])
+	}
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
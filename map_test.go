@@ -0,0 +1,151 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestInsertMap(t *testing.T) {
+	data := map[string]any{"name": "Alice", "email": "alice@example.com", "id": 1, "age": 30}
+	wantSQL := "INSERT INTO users (age, email, id, name) VALUES ($1, $2, $3, $4)"
+	wantArgs := []any{30, "alice@example.com", 1, "Alice"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	// Map iteration order is random, repeat to catch unstable ordering
+	for i := 0; i < 20; i++ {
+		err := s.InsertMap("users", data, &gotSQL, &gotArgs)
+		if err != nil {
+			t.Fatalf("InsertMap error: %v", err)
+		}
+
+		if gotSQL != wantSQL {
+			t.Fatalf("InsertMap SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+		}
+
+		if !reflect.DeepEqual(gotArgs, wantArgs) {
+			t.Fatalf("InsertMap args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+		}
+	}
+}
+
+func TestInsertMapInvalid(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.InsertMap("users", nil, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("InsertMap expected error for empty data, got nil")
+	}
+	if err := s.InsertMap("users", map[string]any{"name) --": 1}, &gotSQL, &gotArgs); err == nil {
+		t.Fatalf("InsertMap expected error for invalid column, got SQL: %s", gotSQL)
+	}
+	if err := s.InsertMap("users; drop", map[string]any{"name": 1}, &gotSQL, &gotArgs); err == nil {
+		t.Fatalf("InsertMap expected error for invalid table, got SQL: %s", gotSQL)
+	}
+}
+
+func TestUpdateMap(t *testing.T) {
+	data := map[string]any{"name": "Alice", "email": "alice@example.com", "age": 30}
+	wantSQL := "UPDATE users SET age=$1, email=$2, name=$3 WHERE id=$4"
+	wantArgs := []any{30, "alice@example.com", "Alice", 7}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	// Map iteration order is random, repeat to catch unstable ordering
+	for i := 0; i < 20; i++ {
+		err := s.UpdateMap("users", 7, data, &gotSQL, &gotArgs)
+		if err != nil {
+			t.Fatalf("UpdateMap error: %v", err)
+		}
+
+		if gotSQL != wantSQL {
+			t.Fatalf("UpdateMap SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+		}
+
+		if !reflect.DeepEqual(gotArgs, wantArgs) {
+			t.Fatalf("UpdateMap args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+		}
+	}
+}
+
+func TestUpdateMapSQLServer(t *testing.T) {
+	wantSQL := "UPDATE [users] SET [name]=@p1 WHERE [id]=@p2"
+	wantArgs := []any{"Alice", 7}
+
+	s := structsql.New(structsql.SQLServer)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.UpdateMap("users", 7, map[string]any{"name": "Alice"}, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("UpdateMap error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("UpdateMap SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("UpdateMap args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdateMapEmpty(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.UpdateMap("users", 7, map[string]any{}, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("UpdateMap expected error for empty data, got nil")
+	}
+}
+
+func TestUpsertMap(t *testing.T) {
+	data := map[string]any{"tenant_id": 3, "sku": "A-1", "name": "Widget", "price": 9.5}
+	wantSQL := "INSERT INTO products (name, price, sku, tenant_id) VALUES ($1, $2, $3, $4)" +
+		" ON CONFLICT (tenant_id, sku) DO UPDATE SET name=$5, price=$6"
+	wantArgs := []any{"Widget", 9.5, "A-1", 3, "Widget", 9.5}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	// Map iteration order is random, repeat to catch unstable ordering
+	for i := 0; i < 20; i++ {
+		err := s.UpsertMap("products", []string{"tenant_id", "sku"}, data, &gotSQL, &gotArgs)
+		if err != nil {
+			t.Fatalf("UpsertMap error: %v", err)
+		}
+
+		if gotSQL != wantSQL {
+			t.Fatalf("UpsertMap SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+		}
+
+		if !reflect.DeepEqual(gotArgs, wantArgs) {
+			t.Fatalf("UpsertMap args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+		}
+	}
+
+	if err := s.UpsertMap("products", []string{"code"}, data, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("UpsertMap expected error for a conflict column missing from data, got nil")
+	}
+
+	// A repeated conflict column still leaves name and price to update
+	err := s.UpsertMap("products", []string{"tenant_id", "sku", "sku", "tenant_id"}, data, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("UpsertMap error with repeated conflict columns: %v", err)
+	}
+
+	// Every column in the conflict target leaves nothing to update
+	all := []string{"tenant_id", "sku", "name", "price"}
+	if err := s.UpsertMap("products", all, data, &gotSQL, &gotArgs); err == nil {
+		t.Fatalf("UpsertMap expected error without SET columns, got SQL: %s", gotSQL)
+	}
+}
@@ -0,0 +1,95 @@
+package structsql
+
+import (
+	"github.com/cdvelop/tinyreflect"
+	. "github.com/cdvelop/tinystring"
+)
+
+// InsertNamed generates INSERT INTO users (name, email) VALUES (:name, :email) with
+// named parameters, as used by sqlx and pgx named queries. names and values receive
+// the parameter name and value of each column, sqlx.InsertNamed pairs them as
+// sql.NamedArg.
+func (s *Structsql) InsertNamed(structTable any, query *string, names *[]string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "INSERT INTO ")
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " (")
+
+	// Columns, auto generated ones are left to the database
+	colCount := 0
+	for i := 0; i < numFields; i++ {
+		if info.fields[i].Auto {
+			continue
+		}
+		if colCount > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[i].Name, c)
+		colCount++
+	}
+
+	if colCount == 0 {
+		return Err("no fields to insert")
+	}
+
+	c.WrString(BuffOut, ") VALUES (")
+
+	// Named placeholders
+	colCount = 0
+	for i := 0; i < numFields; i++ {
+		if info.fields[i].Auto {
+			continue
+		}
+		if colCount > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		c.WrString(BuffOut, ":")
+		c.WrString(BuffOut, info.fields[i].Name)
+		colCount++
+	}
+
+	c.WrString(BuffOut, ")")
+
+	if err := s.setSQL(c, query); err != nil {
+		return err
+	}
+
+	// Populate names and values in column order
+	*names = (*names)[:0]
+	for i := 0; i < numFields; i++ {
+		if !info.fields[i].Auto {
+			*names = append(*names, info.fields[i].Name)
+		}
+	}
+
+	if err := s.ensureCapacity(values, colCount); err != nil {
+		return err
+	}
+	return s.appendInsertValues(tinyreflect.ValueOf(structTable), info, values)
+}
@@ -0,0 +1,37 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestInsertNamed(t *testing.T) {
+	u := AutoUser{Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (name, email) VALUES (:name, :email)"
+	wantNames := []string{"name", "email"}
+	wantValues := []any{"Alice", "alice@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	var gotNames []string
+	gotValues := make([]any, 0, 10)
+
+	err := s.InsertNamed(u, &gotSQL, &gotNames, &gotValues)
+	if err != nil {
+		t.Fatalf("InsertNamed error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("InsertNamed SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotNames, wantNames) {
+		t.Fatalf("InsertNamed names mismatch:\n got: %v\nwant: %v", gotNames, wantNames)
+	}
+
+	if !reflect.DeepEqual(gotValues, wantValues) {
+		t.Fatalf("InsertNamed values mismatch:\n got: %v\nwant: %v", gotValues, wantValues)
+	}
+}
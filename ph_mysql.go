@@ -0,0 +1,15 @@
+package structsql
+
+import . "github.com/cdvelop/tinystring"
+
+// placeholderMySQL generates MySQL-style placeholders (?, ?, ...)
+func placeholderMySQL(index int, conv *Conv) {
+	conv.WrString(BuffOut, "?")
+}
+
+// quoteMySQL wraps identifiers in backticks (`users`, `order`, ...)
+func quoteMySQL(name string, conv *Conv) {
+	conv.WrString(BuffOut, "`")
+	conv.WrString(BuffOut, name)
+	conv.WrString(BuffOut, "`")
+}
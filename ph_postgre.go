@@ -5,6 +5,12 @@ import . "github.com/cdvelop/tinystring"
 // placeholderPostgre generates PostgreSQL-style placeholders ($1, $2, ...)
 func placeholderPostgre(index int, conv *Conv) {
 	conv.WrString(BuffOut, "$")
-	// Use AnyToBuff for tested integer-to-string conversion
-	conv.AnyToBuff(BuffOut, index)
+	writeIndex(index, conv)
+}
+
+// quotePostgre wraps identifiers in double quotes ("users", "order", ...)
+func quotePostgre(name string, conv *Conv) {
+	conv.WrString(BuffOut, `"`)
+	conv.WrString(BuffOut, name)
+	conv.WrString(BuffOut, `"`)
 }
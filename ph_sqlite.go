@@ -6,3 +6,10 @@ import . "github.com/cdvelop/tinystring"
 func placeholderSQLite(index int, conv *Conv) {
 	conv.WrString(BuffOut, "?")
 }
+
+// quoteSQLite wraps identifiers in double quotes ("users", "order", ...)
+func quoteSQLite(name string, conv *Conv) {
+	conv.WrString(BuffOut, `"`)
+	conv.WrString(BuffOut, name)
+	conv.WrString(BuffOut, `"`)
+}
@@ -0,0 +1,16 @@
+package structsql
+
+import . "github.com/cdvelop/tinystring"
+
+// placeholderSQLServer generates SQL Server-style placeholders (@p1, @p2, ...)
+func placeholderSQLServer(index int, conv *Conv) {
+	conv.WrString(BuffOut, "@p")
+	writeIndex(index, conv)
+}
+
+// quoteSQLServer wraps identifiers in square brackets ([users], [order], ...)
+func quoteSQLServer(name string, conv *Conv) {
+	conv.WrString(BuffOut, "[")
+	conv.WrString(BuffOut, name)
+	conv.WrString(BuffOut, "]")
+}
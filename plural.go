@@ -0,0 +1,83 @@
+package structsql
+
+import . "github.com/cdvelop/tinystring"
+
+// pluralize returns the English plural of a lowercased table name. Only the last
+// word of names joined by sep is inflected, eg: "user_profile" -> "user_profiles".
+// Names already ending in a plain "s" are considered plural and returned as is,
+// except singulars like "canvas" listed by irregularPlural.
+func pluralize(name, sep string) string {
+	if name == "" {
+		return name
+	}
+
+	prefix, word := "", name
+	if i := LastIndex(name, sep); i >= 0 && sep != "" {
+		prefix, word = name[:i+len(sep)], name[i+len(sep):]
+	}
+
+	if irregular := irregularPlural(word); irregular != "" {
+		return prefix + irregular
+	}
+
+	n := len(word)
+	switch {
+	case HasSuffix(word, "ss"), HasSuffix(word, "us"),
+		HasSuffix(word, "x"), HasSuffix(word, "z"),
+		HasSuffix(word, "ch"), HasSuffix(word, "sh"):
+		return name + "es"
+	case HasSuffix(word, "is"):
+		return name[:len(name)-2] + "es"
+	case HasSuffix(word, "s"):
+		return name
+	case HasSuffix(word, "y") && n > 1 && !isVowel(word[n-2]):
+		return name[:len(name)-1] + "ies"
+	case HasSuffix(word, "fe"):
+		return name[:len(name)-2] + "ves"
+	case HasSuffix(word, "lf"):
+		return name[:len(name)-1] + "ves"
+	}
+
+	return name + "s"
+}
+
+// irregularPlural returns the plural of words that don't follow the regular rules,
+// or an empty string when word is regular.
+func irregularPlural(word string) string {
+	switch word {
+	case "person":
+		return "people"
+	case "man":
+		return "men"
+	case "woman":
+		return "women"
+	case "child":
+		return "children"
+	case "mouse":
+		return "mice"
+	case "goose":
+		return "geese"
+	case "foot":
+		return "feet"
+	case "tooth":
+		return "teeth"
+	case "ox":
+		return "oxen"
+	case "quiz":
+		return "quizzes"
+	case "alias", "atlas", "bias", "canvas":
+		return word + "es" // singular despite the final s
+	case "sheep", "fish", "deer", "series", "species", "news",
+		"data", "equipment", "information", "metadata":
+		return word
+	}
+	return ""
+}
+
+func isVowel(b byte) bool {
+	switch b {
+	case 'a', 'e', 'i', 'o', 'u':
+		return true
+	}
+	return false
+}
@@ -0,0 +1,78 @@
+package structsql_test
+
+import (
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestTableNamePlural(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   any
+		wantSQL string
+	}{
+		{"regular", User{}, "SELECT id, name, email FROM users"},
+		{"irregular", Person{}, "SELECT id, name FROM people"},
+		{"ending in ss", Address{}, "SELECT id, street FROM addresses"},
+		{"doubled z", Quiz{}, "SELECT id, title FROM quizzes"},
+		{"singular ending in as", Canvas{}, "SELECT id, width FROM canvases"},
+		{"alias", Alias{}, "SELECT id, name FROM aliases"},
+	}
+
+	s := structsql.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSQL string
+			if err := s.SelectAll(tt.table, &gotSQL); err != nil {
+				t.Fatalf("SelectAll error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestTableNaming(t *testing.T) {
+	tests := []struct {
+		name    string
+		naming  structsql.TableNaming
+		table   any
+		wantSQL string
+	}{
+		{"english y", structsql.PluralEnglish, Category{}, "SELECT id, name FROM categories"},
+		{"english x", structsql.PluralEnglish, Box{}, "SELECT id, size FROM boxes"},
+		{"simple", structsql.PluralSimple, Box{}, "SELECT id, size FROM boxs"},
+		{"singular", structsql.Singular, Category{}, "SELECT id, name FROM category"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.naming)
+			var gotSQL string
+			if err := s.SelectAll(tt.table, &gotSQL); err != nil {
+				t.Fatalf("SelectAll error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestTableNamingWithDialect(t *testing.T) {
+	u := User{ID: 1}
+	wantSQL := `DELETE FROM "user" WHERE id=?` // user is a reserved word
+
+	s := structsql.New(structsql.SQLite, structsql.Singular)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Delete(u, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+	if gotSQL != wantSQL {
+		t.Fatalf("Delete SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
@@ -0,0 +1,54 @@
+package structsql
+
+import (
+	"unsafe"
+
+	"github.com/cdvelop/tinyreflect"
+	. "github.com/cdvelop/tinystring"
+)
+
+// InsertPtr is Insert for the struct of type typ stored at ptr. Hot loops reusing
+// one type resolve typ once and skip boxing the struct into an interface, which
+// copies it to the heap when passed by value:
+//
+//	typ := tinyreflect.TypeOf(User{})
+//	for i := range users {
+//		err := s.InsertPtr(typ, unsafe.Pointer(&users[i]), &sql, &values)
+//	}
+//
+// values reference the fields at ptr, so they must be used before the struct
+// changes. TableName is only found when declared with a value receiver.
+func (s *Structsql) InsertPtr(typ *tinyreflect.Type, ptr unsafe.Pointer, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	structTable, err := structAt(typ, ptr)
+	if err != nil {
+		return err
+	}
+
+	return s.insert(structTable, sql, values)
+}
+
+// structAt returns the struct of type typ at ptr as an interface without copying it
+func structAt(typ *tinyreflect.Type, ptr unsafe.Pointer) (any, error) {
+	if typ == nil {
+		return nil, ErrNilInput
+	}
+	if ptr == nil {
+		return nil, ErrNilPointer
+	}
+	if typ.Kind() != K.Struct {
+		return nil, ErrNotStruct
+	}
+
+	var v any
+	e := (*tinyreflect.EmptyInterface)(unsafe.Pointer(&v))
+	e.Type = typ
+	if typ.IfaceIndir() {
+		e.Data = ptr
+	} else {
+		e.Data = *(*unsafe.Pointer)(ptr) // single pointer field struct stored in the interface word
+	}
+	return v, nil
+}
@@ -0,0 +1,81 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+
+	"github.com/cdvelop/structsql"
+	"github.com/cdvelop/tinyreflect"
+)
+
+func TestInsertPtr(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)"
+	wantArgs := []any{1, "Alice", "alice@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.InsertPtr(tinyreflect.TypeOf(u), unsafe.Pointer(&u), &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("InsertPtr error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("InsertPtr SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("InsertPtr args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertPtrInvalid(t *testing.T) {
+	u := User{ID: 1}
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.InsertPtr(nil, unsafe.Pointer(&u), &gotSQL, &gotArgs); err != structsql.ErrNilInput {
+		t.Fatalf("InsertPtr nil type error = %v, want ErrNilInput", err)
+	}
+	if err := s.InsertPtr(tinyreflect.TypeOf(u), nil, &gotSQL, &gotArgs); err != structsql.ErrNilPointer {
+		t.Fatalf("InsertPtr nil pointer error = %v, want ErrNilPointer", err)
+	}
+	if err := s.InsertPtr(tinyreflect.TypeOf(&u), unsafe.Pointer(&u), &gotSQL, &gotArgs); err != structsql.ErrNotStruct {
+		t.Fatalf("InsertPtr pointer type error = %v, want ErrNotStruct", err)
+	}
+}
+
+// BenchmarkInsertBoxed is the baseline of BenchmarkInsertPtr, the struct changes
+// every iteration as in a real loop so passing it by value boxes a new copy
+func BenchmarkInsertBoxed(b *testing.B) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	s := structsql.New()
+	var sql string
+	args := make([]any, 0, 10)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		u.ID = i
+		args = args[:0]
+		_ = s.Insert(u, &sql, &args)
+	}
+}
+
+func BenchmarkInsertPtr(b *testing.B) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	typ := tinyreflect.TypeOf(u)
+	s := structsql.New()
+	var sql string
+	args := make([]any, 0, 10)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		u.ID = i
+		args = args[:0]
+		_ = s.InsertPtr(typ, unsafe.Pointer(&u), &sql, &args)
+	}
+}
@@ -0,0 +1,65 @@
+package structsql
+
+import . "github.com/cdvelop/tinystring"
+
+// CallProc generates the call of the stored procedure or function name with one
+// placeholder per argument, args are copied into values:
+//
+//	PostgreSQL: SELECT proc($1, $2)
+//	MySQL:      CALL proc(?, ?)
+//	SQL Server: EXEC proc @p1, @p2
+//
+// SQLite has no stored procedures and returns an error. name must be a plain
+// identifier, it is qualified by the configured Schema.
+func (s *Structsql) CallProc(name string, args []any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !isIdentifier(name) {
+		return Err("invalid procedure name", name)
+	}
+
+	var start, end string
+	switch s.dbType {
+	case PostgreSQL:
+		start, end = "SELECT ", ")"
+	case MySQL:
+		start, end = "CALL ", ")"
+	case SQLServer:
+		start = "EXEC "
+	default:
+		return Err("stored procedures not supported by database type", string(s.dbType))
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, start)
+	s.quoteTable(name, c)
+	if end != "" {
+		c.WrString(BuffOut, "(")
+	} else if len(args) > 0 {
+		c.WrString(BuffOut, " ")
+	}
+	for i := range args {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.placeholder(i+1, c)
+	}
+	c.WrString(BuffOut, end)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	if err := s.ensureCapacity(values, len(args)); err != nil {
+		return err
+	}
+	s.appendArgs(values, args...)
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestCallProc(t *testing.T) {
+	args := []any{1, "Alice"}
+
+	tests := []struct {
+		name    string
+		configs []any
+		wantSQL string
+	}{
+		{"postgres", nil, "SELECT rename_user($1, $2)"},
+		{"mysql", []any{structsql.MySQL}, "CALL `rename_user`(?, ?)"},
+		{"sqlserver", []any{structsql.SQLServer}, "EXEC [rename_user] @p1, @p2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.configs...)
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := s.CallProc("rename_user", args, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("CallProc error: %v", err)
+			}
+
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("CallProc SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+
+			if !reflect.DeepEqual(gotArgs, args) {
+				t.Fatalf("CallProc args mismatch:\n got: %v\nwant: %v", gotArgs, args)
+			}
+		})
+	}
+}
+
+func TestCallProcErrors(t *testing.T) {
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := structsql.New(structsql.SQLite).CallProc("rename_user", nil, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("CallProc expected error for SQLite, got nil")
+	}
+	if err := structsql.New().CallProc("x(); DROP TABLE users", nil, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("CallProc expected error for invalid name, got nil")
+	}
+}
@@ -0,0 +1,76 @@
+package structsql
+
+import . "github.com/cdvelop/tinystring"
+
+// QuoteMode selects which identifiers are wrapped in the database type quotes.
+// By default MySQL and SQL Server quote every identifier while PostgreSQL and
+// SQLite only quote reserved words, eg: SELECT id, "order" FROM orders. PostgreSQL
+// also quotes names with uppercase letters, which it would otherwise fold to lowercase.
+type QuoteMode string
+
+const (
+	QuoteAlways   QuoteMode = "always"   // "id", "name", "order"
+	QuoteReserved QuoteMode = "reserved" // id, name, "order"
+)
+
+// quote writes a table or column name, quoted when the QuoteMode requires it
+func (s *Structsql) quote(name string, conv *Conv) {
+	if s.needsQuote(name) {
+		s.dbType.quote(name, conv)
+		return
+	}
+	conv.WrString(BuffOut, name)
+}
+
+func (s *Structsql) needsQuote(name string) bool {
+	switch s.quoteMode {
+	case QuoteAlways:
+		return true
+	case QuoteReserved:
+		return isReserved(name) || s.foldsCase(name)
+	}
+	if s.dbType == MySQL || s.dbType == SQLServer {
+		return true
+	}
+	return isReserved(name) || s.foldsCase(name)
+}
+
+// foldsCase reports whether PostgreSQL would lowercase the unquoted name, eg: an
+// AsIs column FirstName must be written "FirstName" to keep its case
+func (s *Structsql) foldsCase(name string) bool {
+	if s.dbType != PostgreSQL {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if isUpper(name[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReserved reports whether name is a common SQL reserved word that can't be
+// used unquoted as a table or column name. The comparison ignores ASCII case.
+func isReserved(name string) bool {
+	var lower [len("constraint")]byte
+	if len(name) > len(lower) {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		b := name[i]
+		if isUpper(b) {
+			b += 'a' - 'A'
+		}
+		lower[i] = b
+	}
+	switch string(lower[:len(name)]) {
+	case "all", "and", "as", "asc", "between", "by", "case", "check", "column",
+		"constraint", "create", "default", "delete", "desc", "distinct", "drop",
+		"else", "end", "exists", "from", "group", "having", "in", "index", "insert",
+		"into", "is", "join", "key", "like", "limit", "not", "null", "offset", "on",
+		"or", "order", "primary", "references", "select", "set", "table", "then",
+		"to", "union", "unique", "update", "user", "values", "when", "where":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,35 @@
+package structsql
+
+type sqliteRowID bool
+
+// SQLiteRowID passed to New keys SQLite structs without a primary key on the
+// implicit rowid of their table, so the verbs matching one row, like Select, Update,
+// Exists and Delete, generate WHERE rowid=? instead of returning ErrNoPrimaryKey.
+// The value is read from the field mapped to rowid:
+//
+//	type Note struct {
+//		RowID int64  `db:"rowid,auto"` // assigned by SQLite, left out of Insert
+//		Text  string `db:"text"`
+//	}
+//
+// CreateTable leaves the rowid field out since the column is implicit. Other
+// database types ignore the option.
+const SQLiteRowID sqliteRowID = true
+
+// rowIDIndex returns the index in info.fields of the field mapped to the implicit
+// rowid when SQLiteRowID applies to info, or -1.
+func (s *Structsql) rowIDIndex(info *typeInfo) int {
+	if !s.rowID || s.dbType != SQLite || info.pkIndex != -1 {
+		return -1
+	}
+	return info.columnIndex("rowid")
+}
+
+// keyIndex returns the index in info.fields of the column the verbs matching one
+// row key on: the primary key or, with SQLiteRowID, the rowid field.
+func (s *Structsql) keyIndex(info *typeInfo) (int, error) {
+	if i := s.rowIDIndex(info); i != -1 {
+		return i, nil
+	}
+	return info.primaryKey()
+}
@@ -0,0 +1,109 @@
+package structsql_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestSQLiteRowID(t *testing.T) {
+	l := LogLine{RowID: 5, Message: "started"}
+	s := structsql.New(structsql.SQLite, structsql.SQLiteRowID)
+
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Delete(l, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+	if want := "DELETE FROM loglines WHERE rowid=?"; gotSQL != want {
+		t.Fatalf("Delete SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+	if want := []any{int64(5)}; !reflect.DeepEqual(gotArgs, want) {
+		t.Fatalf("Delete args mismatch:\n got: %v\nwant: %v", gotArgs, want)
+	}
+
+	if err := s.Select(l, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Select error: %v", err)
+	}
+	if want := "SELECT rowid, message FROM loglines WHERE rowid=?"; gotSQL != want {
+		t.Fatalf("Select SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+
+	if err := s.Insert(l, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	if want := "INSERT INTO loglines (message) VALUES (?)"; gotSQL != want {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+
+	if err := s.CreateTable(l, &gotSQL); err != nil {
+		t.Fatalf("CreateTable error: %v", err)
+	}
+	if want := "CREATE TABLE loglines (message TEXT)"; gotSQL != want {
+		t.Fatalf("CreateTable SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+
+	// Without the option, or on other database types, the struct has no key
+	for _, s := range []*structsql.Structsql{
+		structsql.New(structsql.SQLite),
+		structsql.New(structsql.PostgreSQL, structsql.SQLiteRowID),
+	} {
+		if err := s.Delete(l, &gotSQL, &gotArgs); !errors.Is(err, structsql.ErrNoPrimaryKey) {
+			t.Fatalf("Delete error = %v, want ErrNoPrimaryKey", err)
+		}
+	}
+}
+
+func TestSQLiteRowIDVerbs(t *testing.T) {
+	m := Memo{RowID: 7, Text: "call back"}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := structsql.New(structsql.SQLite, structsql.SQLiteRowID, structsql.NowFunc(func() time.Time { return now }))
+
+	tests := []struct {
+		name     string
+		build    func(sql *string, args *[]any) error
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"SelectColumns", func(sql *string, args *[]any) error {
+			return s.SelectColumns(m, []string{"text"}, sql, args)
+		}, "SELECT text FROM memos WHERE rowid=?", []any{int64(7)}},
+		{"Exists", func(sql *string, args *[]any) error {
+			return s.Exists(m, sql, args)
+		}, "SELECT EXISTS(SELECT 1 FROM memos WHERE rowid=?)", []any{int64(7)}},
+		{"Update", func(sql *string, args *[]any) error {
+			return s.Update(m, sql, args)
+		}, "UPDATE memos SET text=? WHERE rowid=?", []any{"call back", int64(7)}},
+		{"UpdateColumns", func(sql *string, args *[]any) error {
+			return s.UpdateColumns(m, []string{"text"}, sql, args)
+		}, "UPDATE memos SET text=? WHERE rowid=?", []any{"call back", int64(7)}},
+		{"UpdateBatch", func(sql *string, args *[]any) error {
+			return s.UpdateBatch([]Memo{m}, sql, args)
+		}, "UPDATE memos SET text=CASE rowid WHEN ? THEN ? ELSE text END, deleted_at=CASE rowid WHEN ? THEN ? ELSE deleted_at END WHERE rowid IN (?)",
+			[]any{int64(7), "call back", int64(7), nil, int64(7)}},
+		{"SoftDelete", func(sql *string, args *[]any) error {
+			return s.SoftDelete(m, sql, args)
+		}, "UPDATE memos SET deleted_at=? WHERE rowid=?", []any{now, int64(7)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := tt.build(&gotSQL, &gotArgs); err != nil {
+				t.Fatalf("%s error: %v", tt.name, err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("%s SQL mismatch:\n got: %s\nwant: %s", tt.name, gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("%s args mismatch:\n got: %v\nwant: %v", tt.name, gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package structsql
+
+import (
+	"unsafe"
+
+	"github.com/cdvelop/tinyreflect"
+)
+
+// Runtime functions behind package reflect, reached through linkname so the
+// package doesn't import reflect. They are part of the runtime linkname contract,
+// see go.dev/issue/67401.
+
+// unsafe_New allocates a zero value of typ, its pointers visible to the GC
+//
+//go:linkname unsafe_New reflect.unsafe_New
+func unsafe_New(typ *tinyreflect.Type) unsafe.Pointer
+
+// typedmemmove copies the value of typ at src to dst with the GC write barriers
+//
+//go:linkname typedmemmove reflect.typedmemmove
+func typedmemmove(typ *tinyreflect.Type, dst, src unsafe.Pointer)
+
+// resolveTypeOff returns the type at offset off from the module of rtype
+//
+//go:linkname resolveTypeOff reflect.resolveTypeOff
+func resolveTypeOff(rtype unsafe.Pointer, off int32) unsafe.Pointer
+
+// pointerTo returns the type *T of typ T, or nil when the program has no *T type
+func pointerTo(typ *tinyreflect.Type) *tinyreflect.Type {
+	if typ.PtrToThis == 0 {
+		return nil
+	}
+	return (*tinyreflect.Type)(resolveTypeOff(unsafe.Pointer(typ), int32(typ.PtrToThis)))
+}
@@ -0,0 +1,171 @@
+package structsql
+
+import (
+	"unsafe"
+
+	"github.com/cdvelop/tinyreflect"
+	. "github.com/cdvelop/tinystring"
+)
+
+// RowScanner is implemented by *sql.Row and *sql.Rows
+type RowScanner interface {
+	Scan(dest ...any) error
+}
+
+// ScanRow reads the current row of row into dest, a pointer to struct, expecting
+// the columns in the order generated by Select and SelectAll. Fields are passed by
+// address to Scan so database/sql converts the values like for any Scan call:
+// sql.Scanner fields such as sql.NullString scan themselves and NULL requires a
+// pointer or Scanner field, a NULL pointer field is set to nil.
+func (s *Structsql) ScanRow(row RowScanner, dest any) error {
+	if dest == nil {
+		return ErrNilInput
+	}
+	if tinyreflect.TypeOf(dest).Kind() != K.Pointer {
+		return Err("destination must be a pointer to struct")
+	}
+
+	structTable := dest
+	s.mu.Lock()
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	if _, err := s.setupConv(); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	info, err := s.getTypeInfo(typ)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+
+	// Scan targets: &field, or a holder assigned by hand for pointer fields and
+	// types whose pointer type isn't in the program
+	base := (*tinyreflect.EmptyInterface)(unsafe.Pointer(&dest)).Data
+	targets := make([]any, numFields)
+	var holders []any
+	for i := range info.fields {
+		f := &info.fields[i]
+		if f.Kind != K.Pointer {
+			if ptr := pointerTo(f.Typ); ptr != nil {
+				e := (*tinyreflect.EmptyInterface)(unsafe.Pointer(&targets[i]))
+				e.Type = ptr
+				e.Data = unsafe.Add(base, f.Offset)
+				continue
+			}
+		}
+		if holders == nil {
+			holders = make([]any, numFields)
+		}
+		targets[i] = &holders[i]
+	}
+
+	if err := row.Scan(targets...); err != nil {
+		return err
+	}
+	if holders == nil {
+		return nil
+	}
+
+	val, err := tinyreflect.ValueOf(dest).Elem()
+	if err != nil {
+		return err
+	}
+	for i := range info.fields {
+		if _, ok := targets[i].(*any); !ok {
+			continue
+		}
+		fieldVal, err := info.fields[i].value(val)
+		if err != nil {
+			return err
+		}
+		if err := assignScanned(fieldVal, holders[i]); err != nil {
+			return Err("can't scan column", info.fields[i].Name, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// assignScanned stores the driver value src into the struct field fieldVal. A
+// pointer field is set to nil for NULL or to a new value holding src.
+func assignScanned(fieldVal tinyreflect.Value, src any) error {
+	if fieldVal.Kind() == K.Pointer {
+		var ptr any
+		e := (*tinyreflect.EmptyInterface)(unsafe.Pointer(&ptr))
+		e.Type = fieldVal.Type()
+		if src != nil {
+			e.Data = unsafe_New(fieldVal.Type().Elem())
+			elem, err := tinyreflect.ValueOf(ptr).Elem()
+			if err != nil {
+				return err
+			}
+			if err := assignScanned(elem, src); err != nil {
+				return err
+			}
+		}
+		return fieldVal.Set(tinyreflect.ValueOf(ptr))
+	}
+
+	if src == nil {
+		return Err("unsupported value type", "nil")
+	}
+	switch fieldVal.Kind() {
+	case K.String:
+		switch v := src.(type) {
+		case string:
+			return fieldVal.SetString(v)
+		case []byte:
+			return fieldVal.SetString(string(v))
+		}
+	case K.Int, K.Int8, K.Int16, K.Int32, K.Int64:
+		switch v := src.(type) {
+		case int64:
+			return fieldVal.SetInt(v)
+		case int:
+			return fieldVal.SetInt(int64(v))
+		}
+	case K.Uint, K.Uint8, K.Uint16, K.Uint32, K.Uint64:
+		switch v := src.(type) {
+		case int64:
+			return fieldVal.SetUint(uint64(v))
+		case uint64:
+			return fieldVal.SetUint(v)
+		}
+	case K.Float32, K.Float64:
+		switch v := src.(type) {
+		case float64:
+			return fieldVal.SetFloat(v)
+		case int64:
+			return fieldVal.SetFloat(float64(v))
+		}
+	case K.Bool:
+		switch v := src.(type) {
+		case bool:
+			return fieldVal.SetBool(v)
+		case int64:
+			return fieldVal.SetBool(v != 0)
+		}
+	case K.Slice:
+		if v, ok := src.([]byte); ok {
+			// drivers may reuse the buffer after the next Scan
+			return fieldVal.SetBytes(append([]byte(nil), v...))
+		}
+	}
+
+	// Same type on both sides, eg: time.Time columns
+	srcVal := tinyreflect.ValueOf(src)
+	if srcVal.Type() == fieldVal.Type() {
+		return fieldVal.Set(srcVal)
+	}
+
+	return Err("unsupported value type", srcVal.Type().Name())
+}
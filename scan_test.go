@@ -0,0 +1,137 @@
+package structsql_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cdvelop/structsql"
+)
+
+// rowsDriver is a database/sql driver returning one canned row to every query
+type rowsDriver struct {
+	values []driver.Value
+}
+
+func (d rowsDriver) Connect(context.Context) (driver.Conn, error) { return rowsConn{d}, nil }
+func (d rowsDriver) Driver() driver.Driver                        { return nil }
+
+type rowsConn struct{ d rowsDriver }
+
+func (c rowsConn) Prepare(query string) (driver.Stmt, error) { return rowsStmt{c.d}, nil }
+func (c rowsConn) Close() error                              { return nil }
+func (c rowsConn) Begin() (driver.Tx, error)                 { return nil, driver.ErrSkip }
+
+type rowsStmt struct{ d rowsDriver }
+
+func (s rowsStmt) Close() error  { return nil }
+func (s rowsStmt) NumInput() int { return -1 }
+func (s rowsStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, driver.ErrSkip
+}
+func (s rowsStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &cannedRows{values: s.d.values}, nil
+}
+
+type cannedRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *cannedRows) Columns() []string {
+	columns := make([]string, len(r.values))
+	for i := range columns {
+		columns[i] = "c"
+	}
+	return columns
+}
+func (r *cannedRows) Close() error { return nil }
+func (r *cannedRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	copy(dest, r.values)
+	return nil
+}
+
+// queryRow returns a *sql.Row holding values
+func queryRow(t *testing.T, values ...driver.Value) *sql.Row {
+	db := sql.OpenDB(rowsDriver{values: values})
+	t.Cleanup(func() { db.Close() })
+	return db.QueryRow("SELECT")
+}
+
+func TestScanRow(t *testing.T) {
+	row := queryRow(t, int64(7), []byte("Alice"), "alice@example.com")
+	want := User{ID: 7, Name: "Alice", Email: "alice@example.com"}
+
+	s := structsql.New()
+	var got User
+	if err := s.ScanRow(row, &got); err != nil {
+		t.Fatalf("ScanRow error: %v", err)
+	}
+
+	if got != want {
+		t.Fatalf("ScanRow mismatch:\n got: %+v\nwant: %+v", got, want)
+	}
+}
+
+func TestScanRowTime(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	row := queryRow(t, int64(1), "Hello", created, created)
+
+	s := structsql.New()
+	var got Post
+	if err := s.ScanRow(row, &got); err != nil {
+		t.Fatalf("ScanRow error: %v", err)
+	}
+
+	if got.ID != 1 || got.Title != "Hello" || !got.CreatedAt.Equal(created) || !got.UpdatedAt.Equal(created) {
+		t.Fatalf("ScanRow mismatch: %+v", got)
+	}
+}
+
+func TestScanRowPointerAndScanner(t *testing.T) {
+	s := structsql.New()
+
+	var got Reader
+	if err := s.ScanRow(queryRow(t, int64(1), "Alice", "555-0100", "ally", "active"), &got); err != nil {
+		t.Fatalf("ScanRow error: %v", err)
+	}
+	if got.Phone == nil || *got.Phone != "555-0100" {
+		t.Fatalf("ScanRow Phone = %v, want 555-0100", got.Phone)
+	}
+	if want := (sql.NullString{String: "ally", Valid: true}); got.Nickname != want {
+		t.Fatalf("ScanRow Nickname = %+v, want %+v", got.Nickname, want)
+	}
+	if got.ID != 1 || got.Name != "Alice" || got.Stage != "active" {
+		t.Fatalf("ScanRow mismatch: %+v", got)
+	}
+
+	// NULL clears the pointer and invalidates the Scanner
+	if err := s.ScanRow(queryRow(t, int64(1), "Alice", nil, nil, "active"), &got); err != nil {
+		t.Fatalf("ScanRow error: %v", err)
+	}
+	if got.Phone != nil || got.Nickname.Valid {
+		t.Fatalf("ScanRow NULL mismatch: %+v", got)
+	}
+
+	// NULL into a plain field is rejected like database/sql does
+	var u User
+	if err := s.ScanRow(queryRow(t, int64(1), nil, "alice@example.com"), &u); err == nil {
+		t.Fatal("ScanRow expected error for NULL into a string field, got nil")
+	}
+}
+
+func TestScanRowNotPointer(t *testing.T) {
+	s := structsql.New()
+	row := queryRow(t, int64(1), "Alice", "alice@example.com")
+
+	if err := s.ScanRow(row, User{}); err == nil {
+		t.Fatal("ScanRow expected error for non pointer destination, got nil")
+	}
+}
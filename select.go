@@ -1,7 +1,849 @@
 package structsql
 
-func Select(args ...any) error {
+import (
+	"github.com/cdvelop/tinyreflect"
+	. "github.com/cdvelop/tinystring"
+)
+
+func (s *Structsql) Select(structTable any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	v := structTable
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+
+	// Find primary key field index, or the rowid field with SQLiteRowID
+	idIndex, err := s.keyIndex(info)
+	if err != nil {
+		return err
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT ")
+
+	// Columns
+	for i := 0; i < numFields; i++ {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[i].Name, c)
+	}
+
+	c.WrString(BuffOut, " FROM ")
+	s.quoteTable(tableStr, c)
+
+	// WHERE
+	c.WrString(BuffOut, " WHERE ")
+	s.quote(info.fields[idIndex].Name, c)
+	c.WrString(BuffOut, "=")
+	s.placeholder(1, c)
+	s.writeNotDeleted(c, info, true)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	// Populate values
+	if err := s.ensureCapacity(values, 1); err != nil {
+		return err
+	}
+	val := tinyreflect.ValueOf(v)
+	fieldVal, err := info.fields[idIndex].value(val)
+	if err != nil {
+		return err
+	}
+	var iface any
+	if err := bindValue(fieldVal, &iface); err != nil {
+		return err
+	}
+	s.appendArgs(values, iface)
+
+	return nil
+}
+
+// SelectColumns generates SELECT id, name FROM users WHERE id=$1 projecting only
+// the given columns, which must exist in structTable. The primary key value is bound in values.
+func (s *Structsql) SelectColumns(structTable any, columns []string, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	if len(columns) == 0 {
+		return Err("no columns to select")
+	}
+
+	v := structTable
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	// Find primary key field index
+	idIndex, err := s.keyIndex(info)
+	if err != nil {
+		return err
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT ")
+
+	// Columns, validated against the struct
+	for i, column := range columns {
+		colIndex := info.columnIndex(column)
+		if colIndex == -1 {
+			return errDetail(ErrUnknownColumn, column)
+		}
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[colIndex].Name, c)
+	}
+
+	c.WrString(BuffOut, " FROM ")
+	s.quoteTable(tableStr, c)
+
+	// WHERE
+	c.WrString(BuffOut, " WHERE ")
+	s.quote(info.fields[idIndex].Name, c)
+	c.WrString(BuffOut, "=")
+	s.placeholder(1, c)
+	s.writeNotDeleted(c, info, true)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	// Populate values
+	if err := s.ensureCapacity(values, 1); err != nil {
+		return err
+	}
+	val := tinyreflect.ValueOf(v)
+	fieldVal, err := info.fields[idIndex].value(val)
+	if err != nil {
+		return err
+	}
+	var iface any
+	if err := bindValue(fieldVal, &iface); err != nil {
+		return err
+	}
+	s.appendArgs(values, iface)
+
+	return nil
+}
+
+// SelectByColumn generates SELECT id, name, email FROM users WHERE email=$1 to fetch
+// by a unique column other than the primary key. column must exist in structTable
+// and value is bound in values like the field, see appendArgs.
+func (s *Structsql) SelectByColumn(structTable any, column string, value any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	args := [1]any{value}
+	return s.selectBy(structTable, column, args[:], matchEqual, sql, values)
+}
+
+// SelectIn generates SELECT id, name, email FROM users WHERE id IN ($1, $2, $3)
+// with one placeholder per value, the read counterpart of DeleteByIDs. column must
+// exist in structTable and values are copied into outValues.
+func (s *Structsql) SelectIn(structTable any, column string, values []any, sql *string, outValues *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(values) == 0 {
+		return Err("no values provided")
+	}
+
+	return s.selectBy(structTable, column, values, matchIn, sql, outValues)
+}
+
+// SelectAny generates SELECT id, name, email FROM users WHERE id = ANY($1) for
+// PostgreSQL, binding list, a slice such as []int64, as the single value so drivers
+// like pgx send it as one array parameter. Other database types expand list like
+// SelectIn, one placeholder and one value per element.
+func (s *Structsql) SelectAny(structTable any, column string, list any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if list == nil || tinyreflect.TypeOf(list).Kind() != K.Slice {
+		return Err("input is not a slice")
+	}
+
+	elems := tinyreflect.ValueOf(list)
+	n, err := elems.Len()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return Err("no values provided")
+	}
+
+	if s.dbType == PostgreSQL {
+		args := [1]any{list}
+		return s.selectBy(structTable, column, args[:], matchAny, sql, values)
+	}
+
+	args := make([]any, n)
+	for i := range args {
+		elem, err := elems.Index(i)
+		if err != nil {
+			return err
+		}
+		if args[i], err = elem.Interface(); err != nil {
+			return err
+		}
+	}
+	return s.selectBy(structTable, column, args, matchIn, sql, values)
+}
+
+// match selects how selectBy compares the column to its args
+type match int
+
+const (
+	matchEqual match = iota // column=$1
+	matchIn                 // column IN ($1, $2), one placeholder per arg
+	matchAny                // column = ANY($1), PostgreSQL array parameter
+)
+
+// selectBy implements SelectByColumn, SelectIn and SelectAny, comparing column to
+// args as m selects. The caller holds mu.
+func (s *Structsql) selectBy(structTable any, column string, args []any, m match, sql *string, values *[]any) error {
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+
+	colIndex := info.columnIndex(column)
+	if colIndex == -1 {
+		return errDetail(ErrUnknownColumn, column)
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT ")
+	for i := 0; i < numFields; i++ {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[i].Name, c)
+	}
+
+	c.WrString(BuffOut, " FROM ")
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " WHERE ")
+	s.quote(info.fields[colIndex].Name, c)
+	switch m {
+	case matchIn:
+		c.WrString(BuffOut, " IN (")
+		for i := range args {
+			if i > 0 {
+				c.WrString(BuffOut, ", ")
+			}
+			s.placeholder(i+1, c)
+		}
+		c.WrString(BuffOut, ")")
+	case matchAny:
+		c.WrString(BuffOut, " = ANY(")
+		s.placeholder(1, c)
+		c.WrString(BuffOut, ")")
+	default:
+		c.WrString(BuffOut, "=")
+		s.placeholder(1, c)
+	}
+	s.writeNotDeleted(c, info, true)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	if err := s.ensureCapacity(values, len(args)); err != nil {
+		return err
+	}
+	s.appendArgs(values, args...)
+
+	return nil
+}
+
+func (s *Structsql) SelectAll(structTable any, sql *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT ")
+
+	// Columns
+	for i := 0; i < numFields; i++ {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[i].Name, c)
+	}
+
+	c.WrString(BuffOut, " FROM ")
+	s.quoteTable(tableStr, c)
+	s.writeNotDeleted(c, info, false)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// SelectByExample generates SELECT id, name, email FROM users WHERE name=$1 AND email=$2
+// with one predicate per non-zero field of example, in field order, and their
+// values bound in values. example must be of the type of structTable. Zero fields
+// are ignored, so they can't be matched, eg: active=false, and an example without
+// non-zero fields selects every row.
+func (s *Structsql) SelectByExample(structTable any, example any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	exampleTyp, err := s.validateStruct(&example)
+	if err != nil {
+		return err
+	}
+	if exampleTyp != typ {
+		return Err("example type doesn't match", typ.Name())
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+
+	// Collect the non-zero fields of example
+	val := tinyreflect.ValueOf(example)
+	var buf [32]int // on the stack up to 32 columns
+	matched := buf[:0]
+	for i := 0; i < numFields; i++ {
+		fieldVal, err := info.fields[i].value(val)
+		if err != nil {
+			return err
+		}
+		if !isZero(fieldVal) {
+			matched = append(matched, i)
+		}
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT ")
+	for i := 0; i < numFields; i++ {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[i].Name, c)
+	}
+
+	c.WrString(BuffOut, " FROM ")
+	s.quoteTable(tableStr, c)
+	for i, fi := range matched {
+		if i == 0 {
+			c.WrString(BuffOut, " WHERE ")
+		} else {
+			c.WrString(BuffOut, " AND ")
+		}
+		s.quote(info.fields[fi].Name, c)
+		c.WrString(BuffOut, "=")
+		s.placeholder(i+1, c)
+	}
+	s.writeNotDeleted(c, info, len(matched) > 0)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	// Populate values in predicate order
+	if err := s.ensureCapacity(values, len(matched)); err != nil {
+		return err
+	}
+	for _, fi := range matched {
+		if err := s.appendFieldValue(val, &info.fields[fi], values); err != nil {
+			return err
+		}
+	}
 
 	return nil
+}
+
+// SelectAliased generates SELECT u.id, u.name, u.email FROM users u qualifying the
+// table and every column with alias, which must be a plain identifier.
+func (s *Structsql) SelectAliased(structTable any, alias string, sql *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !isIdentifier(alias) {
+		return Err("invalid alias", alias)
+	}
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	if len(info.fields) == 0 {
+		return ErrNoFields
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT ")
+	s.writeAliasedColumns(c, info, alias)
+	c.WrString(BuffOut, " FROM ")
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " ")
+	c.WrString(BuffOut, alias)
+	s.writeNotDeletedAs(c, info, alias, false)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// writeAliasedColumns writes the columns of info separated by commas, each one
+// prefixed with alias, eg: u.id, u.name
+func (s *Structsql) writeAliasedColumns(c *Conv, info *typeInfo, alias string) {
+	for i := range info.fields {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		c.WrString(BuffOut, alias)
+		c.WrString(BuffOut, ".")
+		s.quote(info.fields[i].Name, c)
+	}
+}
+
+// Exists generates SELECT EXISTS(SELECT 1 FROM users WHERE id=$1)
+// with the primary key value of structTable in values.
+func (s *Structsql) Exists(structTable any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	v := structTable
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	// Find primary key field index
+	idIndex, err := s.keyIndex(info)
+	if err != nil {
+		return err
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT EXISTS(SELECT 1 FROM ")
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " WHERE ")
+	s.quote(info.fields[idIndex].Name, c)
+	c.WrString(BuffOut, "=")
+	s.placeholder(1, c)
+	s.writeNotDeleted(c, info, true)
+	c.WrString(BuffOut, ")")
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	// Populate values
+	if err := s.ensureCapacity(values, 1); err != nil {
+		return err
+	}
+	val := tinyreflect.ValueOf(v)
+	fieldVal, err := info.fields[idIndex].value(val)
+	if err != nil {
+		return err
+	}
+	var iface any
+	if err := bindValue(fieldVal, &iface); err != nil {
+		return err
+	}
+	s.appendArgs(values, iface)
+
+	return nil
+}
+
+// SelectOptions shapes the rows returned by SelectList.
+// OrderBy entries are column names optionally followed by ASC or DESC, eg: "name DESC".
+// Limit and Offset are ignored when zero.
+type SelectOptions struct {
+	OrderBy []string
+	Limit   int
+	Offset  int
+}
+
+// SelectList generates SELECT id, name, email FROM users ORDER BY name DESC LIMIT $1 OFFSET $2
+// with limit and offset bound in values. ORDER BY columns must exist in structTable.
+func (s *Structsql) SelectList(structTable any, opts SelectOptions, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.selectList(structTable, opts, false, sql, values)
+}
+
+// selectList implements SelectList, the caller holds mu. keepOffset writes the
+// OFFSET even when zero so every page shares the same SQL and arguments.
+func (s *Structsql) selectList(structTable any, opts SelectOptions, keepOffset bool, sql *string, values *[]any) error {
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
 
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+
+	if opts.Limit < 0 || opts.Offset < 0 {
+		return Err("limit and offset must not be negative")
+	}
+
+	// SQL Server paginates with OFFSET ... FETCH which requires ORDER BY
+	if s.dbType == SQLServer && (opts.Limit > 0 || opts.Offset > 0) && len(opts.OrderBy) == 0 {
+		return Err("order by required for limit or offset by database type", string(s.dbType))
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT ")
+
+	// Columns
+	for i := 0; i < numFields; i++ {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[i].Name, c)
+	}
+
+	c.WrString(BuffOut, " FROM ")
+	s.quoteTable(tableStr, c)
+	s.writeNotDeleted(c, info, false)
+
+	// ORDER BY, columns are validated against the struct to prevent injection
+	for i, order := range opts.OrderBy {
+		column, desc, err := parseOrder(order)
+		if err != nil {
+			return err
+		}
+		colIndex := info.columnIndex(column)
+		if colIndex == -1 {
+			return errDetail(ErrUnknownColumn, column)
+		}
+		if i == 0 {
+			c.WrString(BuffOut, " ORDER BY ")
+		} else {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[colIndex].Name, c)
+		if desc {
+			c.WrString(BuffOut, " DESC")
+		}
+	}
+
+	// LIMIT and OFFSET
+	if err := s.ensureCapacity(values, 2); err != nil {
+		return err
+	}
+	index := 1
+	if s.dbType == SQLServer {
+		if opts.Limit > 0 || opts.Offset > 0 {
+			c.WrString(BuffOut, " OFFSET ")
+			s.placeholder(index, c)
+			c.WrString(BuffOut, " ROWS")
+			*values = append(*values, opts.Offset)
+			index++
+		}
+		if opts.Limit > 0 {
+			c.WrString(BuffOut, " FETCH NEXT ")
+			s.placeholder(index, c)
+			c.WrString(BuffOut, " ROWS ONLY")
+			*values = append(*values, opts.Limit)
+		}
+	} else {
+		if opts.Limit > 0 {
+			c.WrString(BuffOut, " LIMIT ")
+			s.placeholder(index, c)
+			*values = append(*values, opts.Limit)
+			index++
+		}
+		if opts.Offset > 0 || keepOffset {
+			if opts.Limit == 0 && s.dbType != PostgreSQL {
+				// SQLite and MySQL only accept OFFSET after a LIMIT, -1 / max means no limit
+				c.WrString(BuffOut, " LIMIT ")
+				if s.dbType == MySQL {
+					c.WrString(BuffOut, "18446744073709551615")
+				} else {
+					c.WrString(BuffOut, "-1")
+				}
+			}
+			c.WrString(BuffOut, " OFFSET ")
+			s.placeholder(index, c)
+			*values = append(*values, opts.Offset)
+		}
+	}
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Paginate generates the query of one page and the matching count:
+//
+//	dataSQL:  SELECT id, name, email FROM users LIMIT $1 OFFSET $2
+//	countSQL: SELECT COUNT(*) FROM users
+//
+// page starts at 1, values receives pageSize and the offset (page-1)*pageSize and
+// is only bound to dataSQL. SQL Server needs an ORDER BY to paginate, use SelectList.
+func (s *Structsql) Paginate(structTable any, page, pageSize int, dataSQL *string, countSQL *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if page < 1 {
+		return Err("page must be greater than zero")
+	}
+	if pageSize <= 0 {
+		return Err("page size must be greater than zero")
+	}
+
+	opts := SelectOptions{Limit: pageSize, Offset: (page - 1) * pageSize}
+	if err := s.selectList(structTable, opts, true, dataSQL, values); err != nil {
+		return err
+	}
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	// Count the same rows the page is taken from
+	c.WrString(BuffOut, "SELECT COUNT(*) FROM ")
+	s.quoteTable(tableStr, c)
+	s.writeNotDeleted(c, info, false)
+
+	if err := s.setSQL(c, countSQL); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// parseOrder splits an ORDER BY entry like "name DESC" into its column and direction.
+func parseOrder(order string) (column string, desc bool, err error) {
+	column = order
+	if i := Index(order, " "); i >= 0 {
+		column = order[:i]
+		switch order[i+1:] {
+		case "ASC", "asc":
+		case "DESC", "desc":
+			desc = true
+		default:
+			return "", false, Err("invalid order direction", order[i+1:])
+		}
+	}
+	return column, desc, nil
+}
+
+// SelectWhere generates SELECT id, name, email FROM users WHERE name=$1 AND age>$2
+// with the condition values of where appended to values in clause order.
+func (s *Structsql) SelectWhere(structTable any, where *Where, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "SELECT ")
+
+	// Columns
+	for i := 0; i < numFields; i++ {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[i].Name, c)
+	}
+
+	c.WrString(BuffOut, " FROM ")
+	s.quoteTable(tableStr, c)
+
+	if err := s.ensureCapacity(values, where.argCount()); err != nil {
+		return err
+	}
+	if _, err := s.writeWhere(c, info, where, 1, values); err != nil {
+		return err
+	}
+	s.writeNotDeleted(c, info, where != nil && len(where.conds) > 0)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
 }
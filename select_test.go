@@ -1,14 +1,630 @@
 package structsql_test
 
-import "testing"
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
 
 func TestSelect(t *testing.T) {
-	/* wantSQL := "SELECT id, name, email FROM users WHERE id = ?"
-	gotSQL, err := structsql.Select(User{})
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "SELECT id, name, email FROM users WHERE id=$1"
+	wantArgs := []any{1}
+
+	s := structsql.New() // Default PostgreSQL
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Select(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Select error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Select SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Select args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestSelectSQLite(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "SELECT id, name, email FROM users WHERE id=?"
+	wantArgs := []any{1}
+
+	s := structsql.New(structsql.SQLite)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Select(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Select error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Select SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Select args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestSelectPointer(t *testing.T) {
+	u := &User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "SELECT id, name, email FROM users WHERE id=$1"
+	wantArgs := []any{1}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Select(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Select error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Select SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Select args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestSelectTaggedPK(t *testing.T) {
+	p := Profile{UserID: 7}
+	wantSQL := "SELECT user_id, first_name, created_at, bio FROM profiles WHERE user_id=$1"
+	wantArgs := []any{7}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Select(p, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Select error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Select SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Select args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestSelectAllExcludedField(t *testing.T) {
+	wantSQL := "SELECT id, email FROM members"
+
+	s := structsql.New()
+	var gotSQL string
+
+	err := s.SelectAll(Member{}, &gotSQL)
+	if err != nil {
+		t.Fatalf("SelectAll error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestSelectTableNamer(t *testing.T) {
+	wantSQL := "SELECT id, name FROM accounts WHERE id=$1"
+	wantArgs := []any{3}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Select(Customer{ID: 3}, &gotSQL, &gotArgs)
 	if err != nil {
 		t.Fatalf("Select error: %v", err)
 	}
+
 	if gotSQL != wantSQL {
 		t.Fatalf("Select SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
-	} */
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Select args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+// TestTableNamerCallOrder passes the same struct by value and by pointer in both
+// orders, the table must not depend on which call fills the cache first
+func TestTableNamerCallOrder(t *testing.T) {
+	c := Customer{ID: 3, Name: "Alice"}
+	a := Acct{ID: 3, Name: "Alice"}
+	inputs := map[string][2]any{
+		"value then pointer": {c, &c},
+		"pointer then value": {&c, c},
+	}
+
+	for name, calls := range inputs {
+		t.Run(name, func(t *testing.T) {
+			s := structsql.New()
+			for _, row := range calls {
+				var gotSQL string
+				gotArgs := make([]any, 0, 10)
+				if err := s.Insert(row, &gotSQL, &gotArgs); err != nil {
+					t.Fatalf("Insert error: %v", err)
+				}
+				if want := "INSERT INTO accounts (id, name) VALUES ($1, $2)"; gotSQL != want {
+					t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+				}
+			}
+
+			// A pointer receiver TableName is refused by pointer and by value
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+			if err := s.Insert(&a, &gotSQL, &gotArgs); !errors.Is(err, structsql.ErrPointerTableNamer) {
+				t.Fatalf("Insert(&a) error = %v, want ErrPointerTableNamer", err)
+			}
+			if err := s.Insert(a, &gotSQL, &gotArgs); !errors.Is(err, structsql.ErrPointerTableNamer) {
+				t.Fatalf("Insert(a) error = %v, want ErrPointerTableNamer", err)
+			}
+			if err := s.Insert(&a, &gotSQL, &gotArgs); !errors.Is(err, structsql.ErrPointerTableNamer) {
+				t.Fatalf("Insert(&a) after Insert(a) error = %v, want ErrPointerTableNamer", err)
+			}
+		})
+	}
+}
+
+func TestSelectColumns(t *testing.T) {
+	u := User{ID: 1}
+	wantSQL := "SELECT id, name FROM users WHERE id=$1"
+	wantArgs := []any{1}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.SelectColumns(u, []string{"id", "name"}, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("SelectColumns error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("SelectColumns SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("SelectColumns args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestSelectColumnsUnknown(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.SelectColumns(User{ID: 1}, []string{"id", "password"}, &gotSQL, &gotArgs)
+	if !errors.Is(err, structsql.ErrUnknownColumn) {
+		t.Fatalf("SelectColumns error = %v, want ErrUnknownColumn", err)
+	}
+
+	if err := s.SelectColumns(User{ID: 1}, nil, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("SelectColumns expected error for no columns, got nil")
+	}
+}
+
+func TestSelectByColumn(t *testing.T) {
+	wantSQL := "SELECT id, name, email FROM users WHERE email=$1"
+	wantArgs := []any{"alice@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.SelectByColumn(User{}, "email", "alice@example.com", &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("SelectByColumn error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("SelectByColumn SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("SelectByColumn args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	err = s.SelectByColumn(User{}, "password", "secret", &gotSQL, &gotArgs)
+	if !errors.Is(err, structsql.ErrUnknownColumn) {
+		t.Fatalf("SelectByColumn error = %v, want ErrUnknownColumn", err)
+	}
+}
+
+func TestSelectIn(t *testing.T) {
+	ids := []any{1, 2, 3}
+
+	tests := []struct {
+		name    string
+		configs []any
+		wantSQL string
+	}{
+		{"postgres", nil, "SELECT id, name, email FROM users WHERE id IN ($1, $2, $3)"},
+		{"sqlite", []any{structsql.SQLite}, "SELECT id, name, email FROM users WHERE id IN (?, ?, ?)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.configs...)
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := s.SelectIn(User{}, "id", ids, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("SelectIn error: %v", err)
+			}
+
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("SelectIn SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+
+			if !reflect.DeepEqual(gotArgs, ids) {
+				t.Fatalf("SelectIn args mismatch:\n got: %v\nwant: %v", gotArgs, ids)
+			}
+		})
+	}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+	if err := s.SelectIn(User{}, "id", nil, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("SelectIn expected error for empty values, got nil")
+	}
+	if err := s.SelectIn(User{}, "password", ids, &gotSQL, &gotArgs); !errors.Is(err, structsql.ErrUnknownColumn) {
+		t.Fatalf("SelectIn error = %v, want ErrUnknownColumn", err)
+	}
+}
+
+func TestSelectAny(t *testing.T) {
+	ids := []int64{1, 2, 3}
+
+	tests := []struct {
+		name     string
+		configs  []any
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"postgres", nil, "SELECT id, name, email FROM users WHERE id = ANY($1)", []any{ids}},
+		{"sqlite", []any{structsql.SQLite}, "SELECT id, name, email FROM users WHERE id IN (?, ?, ?)",
+			[]any{int64(1), int64(2), int64(3)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.configs...)
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := s.SelectAny(User{}, "id", ids, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("SelectAny error: %v", err)
+			}
+
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("SelectAny SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("SelectAny args mismatch:\n got: %v\nwant: %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+	if err := s.SelectAny(User{}, "id", 1, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("SelectAny expected error for a non slice list, got nil")
+	}
+	if err := s.SelectAny(User{}, "id", []int64{}, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("SelectAny expected error for an empty list, got nil")
+	}
+}
+
+func TestSelectByExample(t *testing.T) {
+	tests := []struct {
+		name     string
+		example  User
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"two fields", User{Name: "Alice", Email: "alice@example.com"},
+			"SELECT id, name, email FROM users WHERE name=$1 AND email=$2",
+			[]any{"Alice", "alice@example.com"}},
+		{"zero example", User{},
+			"SELECT id, name, email FROM users", []any{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New()
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := s.SelectByExample(User{}, tt.example, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("SelectByExample error: %v", err)
+			}
+
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("SelectByExample SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("SelectByExample args mismatch:\n got: %v\nwant: %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+	if err := s.SelectByExample(User{}, Profile{FirstName: "Alice"}, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("SelectByExample expected error for an example of another type, got nil")
+	}
+}
+
+func TestSelectAll(t *testing.T) {
+	u := User{}
+	wantSQL := "SELECT id, name, email FROM users"
+
+	s := structsql.New() // Default PostgreSQL
+	var gotSQL string
+
+	err := s.SelectAll(u, &gotSQL)
+	if err != nil {
+		t.Fatalf("SelectAll error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestSelectAliased(t *testing.T) {
+	u := User{}
+	wantSQL := "SELECT u.id, u.name, u.email FROM users u"
+
+	s := structsql.New()
+	var gotSQL string
+
+	err := s.SelectAliased(u, "u", &gotSQL)
+	if err != nil {
+		t.Fatalf("SelectAliased error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("SelectAliased SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if err := s.SelectAliased(u, "u; DROP TABLE users", &gotSQL); err == nil {
+		t.Fatal("SelectAliased expected error for invalid alias")
+	}
+}
+
+func TestSelectAllSQLite(t *testing.T) {
+	u := User{}
+	wantSQL := "SELECT id, name, email FROM users"
+
+	s := structsql.New(structsql.SQLite)
+	var gotSQL string
+
+	err := s.SelectAll(u, &gotSQL)
+	if err != nil {
+		t.Fatalf("SelectAll error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestSelectList(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     structsql.SelectOptions
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"ascending", structsql.SelectOptions{OrderBy: []string{"name"}},
+			"SELECT id, name, email FROM users ORDER BY name", []any{}},
+		{"descending", structsql.SelectOptions{OrderBy: []string{"name DESC", "id ASC"}},
+			"SELECT id, name, email FROM users ORDER BY name DESC, id", []any{}},
+		{"limit", structsql.SelectOptions{Limit: 10},
+			"SELECT id, name, email FROM users LIMIT $1", []any{10}},
+		{"limit offset", structsql.SelectOptions{OrderBy: []string{"email desc"}, Limit: 10, Offset: 20},
+			"SELECT id, name, email FROM users ORDER BY email DESC LIMIT $1 OFFSET $2", []any{10, 20}},
+		{"offset", structsql.SelectOptions{Offset: 5},
+			"SELECT id, name, email FROM users OFFSET $1", []any{5}},
+	}
+
+	s := structsql.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+			if err := s.SelectList(User{}, tt.opts, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("SelectList error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("SelectList SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("SelectList args mismatch:\n got: %v\nwant: %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestSelectListSQLite(t *testing.T) {
+	wantSQL := "SELECT id, name, email FROM users ORDER BY id DESC LIMIT ? OFFSET ?"
+	wantArgs := []any{10, 20}
+
+	s := structsql.New(structsql.SQLite)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	opts := structsql.SelectOptions{OrderBy: []string{"id DESC"}, Limit: 10, Offset: 20}
+	err := s.SelectList(User{}, opts, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("SelectList error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("SelectList SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("SelectList args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestSelectListInvalidOrder(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	for _, order := range []string{"password", "name; DROP TABLE users", "name DESCENDING"} {
+		opts := structsql.SelectOptions{OrderBy: []string{order}}
+		if err := s.SelectList(User{}, opts, &gotSQL, &gotArgs); err == nil {
+			t.Fatalf("SelectList expected error for order %q, got SQL: %s", order, gotSQL)
+		}
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	wantDataSQL := "SELECT id, name, email FROM users LIMIT $1 OFFSET $2"
+	wantCountSQL := "SELECT COUNT(*) FROM users"
+	wantArgs := []any{10, 10}
+
+	s := structsql.New()
+	var dataSQL, countSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Paginate(User{}, 2, 10, &dataSQL, &countSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Paginate error: %v", err)
+	}
+
+	if dataSQL != wantDataSQL {
+		t.Fatalf("Paginate data SQL mismatch:\n got: %s\nwant: %s", dataSQL, wantDataSQL)
+	}
+
+	if countSQL != wantCountSQL {
+		t.Fatalf("Paginate count SQL mismatch:\n got: %s\nwant: %s", countSQL, wantCountSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Paginate args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestPaginateFirstPage(t *testing.T) {
+	wantDataSQL := "SELECT id, name, email FROM users LIMIT $1 OFFSET $2"
+	wantArgs := []any{10, 0}
+
+	s := structsql.New()
+	var dataSQL, countSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Paginate(User{}, 1, 10, &dataSQL, &countSQL, &gotArgs); err != nil {
+		t.Fatalf("Paginate error: %v", err)
+	}
+
+	if dataSQL != wantDataSQL {
+		t.Fatalf("Paginate data SQL mismatch:\n got: %s\nwant: %s", dataSQL, wantDataSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Paginate args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestPaginateInvalid(t *testing.T) {
+	s := structsql.New()
+	var dataSQL, countSQL string
+	gotArgs := make([]any, 0, 10)
+
+	for _, p := range [][2]int{{0, 10}, {-1, 10}, {1, 0}, {1, -5}} {
+		if err := s.Paginate(User{}, p[0], p[1], &dataSQL, &countSQL, &gotArgs); err == nil {
+			t.Fatalf("Paginate expected error for page %d size %d", p[0], p[1])
+		}
+	}
+}
+
+func TestExists(t *testing.T) {
+	u := User{ID: 1}
+	wantSQL := "SELECT EXISTS(SELECT 1 FROM users WHERE id=$1)"
+	wantArgs := []any{1}
+
+	s := structsql.New() // Default PostgreSQL
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Exists(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Exists error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Exists SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Exists args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestExistsSQLite(t *testing.T) {
+	u := User{ID: 1}
+	wantSQL := "SELECT EXISTS(SELECT 1 FROM users WHERE id=?)"
+	wantArgs := []any{1}
+
+	s := structsql.New(structsql.SQLite)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Exists(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Exists error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Exists SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Exists args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func BenchmarkSelect(b *testing.B) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	s := structsql.New()
+	var sql string
+	args := make([]any, 0, 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		args = args[:0]
+		_ = s.Select(u, &sql, &args)
+	}
+}
+
+func BenchmarkSelectAll(b *testing.B) {
+	u := User{}
+	s := structsql.New()
+	var sql string
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = s.SelectAll(u, &sql)
+	}
 }
@@ -7,42 +7,199 @@ import (
 	. "github.com/cdvelop/tinystring"
 )
 
-func (s *Structsql) validateStruct(structTable any) (*tinyreflect.Type, error) {
-	if structTable == nil {
-		return nil, Err("no struct table provided")
+// validateStruct checks that *structTable holds a named struct and returns its type.
+// A pointer to struct is dereferenced in place, so callers reading field values
+// from *structTable afterwards always see the struct itself.
+func (s *Structsql) validateStruct(structTable *any) (*tinyreflect.Type, error) {
+	typ, err := structType(structTable)
+	if err != nil {
+		return nil, err
 	}
 
-	v := structTable
+	if typ.Name() == "struct" {
+		return nil, ErrNotStructNamer
+	}
 
-	typ := tinyreflect.TypeOf(v)
-	if typ.Kind() != K.Struct {
-		return nil, Err("input is not a struct")
+	namer, isNamer := (*structTable).(TableNamer)
+	if !isNamer && pointerNamer(typ) {
+		return nil, errDetail(ErrPointerTableNamer, typ.Name())
 	}
 
-	if typ.Name() == "struct" {
-		return nil, Err("struct does not implement StructNamer interface")
+	// Seed the table name cache so getTableName uses the override
+	if isNamer {
+		typPtr := uintptr(unsafe.Pointer(typ))
+		if _, ok := s.tableNameCache[typPtr]; !ok {
+			s.tableNameCache[typPtr] = namer.TableName()
+		}
+	}
+
+	return typ, nil
+}
+
+// pointerNamer reports whether *T implements TableNamer while T doesn't, a TableName
+// with a pointer receiver the struct misses however it was passed
+func pointerNamer(typ *tinyreflect.Type) bool {
+	ptr := pointerTo(typ)
+	if ptr == nil {
+		return false
+	}
+	var nilPtr any
+	(*tinyreflect.EmptyInterface)(unsafe.Pointer(&nilPtr)).Type = ptr
+	_, ok := nilPtr.(TableNamer)
+	return ok
+}
+
+// structType checks that *structTable holds a struct, named or anonymous, and
+// returns its type, dereferencing a pointer to struct in place like validateStruct.
+// The struct is copied so values never alias the caller's struct.
+func structType(structTable *any) (*tinyreflect.Type, error) {
+	if *structTable == nil {
+		return nil, ErrNilInput
+	}
+
+	typ := tinyreflect.TypeOf(*structTable)
+	if typ.Kind() == K.Pointer {
+		elem, err := tinyreflect.ValueOf(*structTable).Elem()
+		if err != nil {
+			return nil, err
+		}
+		if elem.Type() == nil {
+			return nil, ErrNilPointer
+		}
+		v, err := elem.Interface()
+		if err != nil {
+			return nil, err
+		}
+		copyInterface(&v)
+		*structTable = v
+		typ = tinyreflect.TypeOf(v)
+	}
+
+	if typ.Kind() != K.Struct {
+		return nil, ErrNotStruct
 	}
 
 	return typ, nil
 }
 
-func (s *Structsql) setupConv() *Conv {
+func (s *Structsql) setupConv() (*Conv, error) {
 	c := s.convPool
+	if c == nil {
+		return nil, ErrClosed
+	}
 	c.ResetBuffer(BuffOut)
 	c.ResetBuffer(BuffWork)
 	c.ResetBuffer(BuffErr)
-	return c
+	s.stmtTable = ""
+	s.pendingKey = builtKey{}
+	return c, nil
+}
+
+// maxSQLCache bounds the interned statements, variable shapes such as IN lists
+// or batches of any size would otherwise grow the cache without limit
+const maxSQLCache = 256
+
+// setSQL publishes the statement built in BuffOut into sql. Statements are interned
+// so repeated shapes don't allocate and the returned string stays valid after
+// BuffOut is reused by the next call, from this or any other goroutine.
+// A message left in BuffErr by a failed conversion while building is returned
+// instead, wrapped in ErrConversion, and sql is left untouched.
+// With Annotate the statement is published behind its comment, see writeAnnotation.
+func (s *Structsql) setSQL(c *Conv, sql *string) error {
+	buff, err := s.builtSQL(c)
+	if err != nil {
+		return err
+	}
+
+	built := c.GetStringZeroCopy(buff)
+	cached, ok := s.sqlCache[built]
+	if !ok {
+		cached = c.GetString(buff)
+		if len(s.sqlCache) < maxSQLCache {
+			s.sqlCache[cached] = cached
+		}
+	}
+	s.storeBuilt(cached)
+	*sql = cached
+	return nil
+}
+
+// setSQLBytes is setSQL copying the statement into the caller's buffer sql instead,
+// which only allocates when sql lacks the capacity. Statements aren't interned.
+func (s *Structsql) setSQLBytes(c *Conv, sql *[]byte) error {
+	buff, err := s.builtSQL(c)
+	if err != nil {
+		return err
+	}
+
+	*sql = append((*sql)[:0], c.GetStringZeroCopy(buff)...)
+	if s.pendingKey.typ != 0 {
+		s.storeBuilt(c.GetString(buff))
+	}
+	return nil
+}
+
+// builtKey identifies a statement fully determined by the struct type, the verb
+// and the database type, only its values change from call to call
+type builtKey struct {
+	typ  uintptr
+	verb string
+	db   dbType
 }
 
+// cachedBuilt returns the statement of verb for typ built by a previous call, so
+// the verb only has to populate the values. On a miss the key is kept pending and
+// setSQL or setSQLBytes store the statement they publish. A SQLHook may not
+// return the same statement twice, so nothing is cached while one is set.
+func (s *Structsql) cachedBuilt(typ *tinyreflect.Type, verb string) (string, bool) {
+	if s.hook != nil {
+		return "", false
+	}
+	key := builtKey{typ: uintptr(unsafe.Pointer(typ)), verb: verb, db: s.dbType}
+	if sql, ok := s.builtCache[key]; ok {
+		return sql, true
+	}
+	s.pendingKey = key
+	return "", false
+}
+
+// storeBuilt caches sql under the pending key of cachedBuilt, if any
+func (s *Structsql) storeBuilt(sql string) {
+	if s.pendingKey.typ != 0 {
+		s.builtCache[s.pendingKey] = sql
+		s.pendingKey = builtKey{}
+	}
+}
+
+// builtSQL returns the buffer holding the statement built in BuffOut: BuffOut itself
+// or, with Annotate or a SQLHook, BuffWork where the final statement is written.
+func (s *Structsql) builtSQL(c *Conv) (BuffDest, error) {
+	if c.GetStringZeroCopy(BuffErr) != "" {
+		return BuffOut, errDetail(ErrConversion, c.GetString(BuffErr))
+	}
+
+	buff := BuffOut
+	if s.annotate {
+		writeAnnotation(c, c.GetStringZeroCopy(BuffOut), s.stmtTable)
+		buff = BuffWork
+	}
+	if s.hook != nil {
+		buff = s.applyHook(c, buff)
+	}
+	s.stmtTable = ""
+
+	return buff, nil
+}
+
+// getTableName returns the table of typ: the TableName of a TableNamer, cached
+// by validateStruct, or the lowercased struct name following the TableNaming.
 func (s *Structsql) getTableName(typ *tinyreflect.Type, tableStr *string) {
 	typPtr := uintptr(unsafe.Pointer(typ))
 
 	// Check cache first
-	for _, entry := range s.tableNameCache {
-		if entry.typePtr == typPtr {
-			*tableStr = entry.tableName
-			return
-		}
+	if cached, ok := s.tableNameCache[typPtr]; ok {
+		*tableStr = cached
+		return
 	}
 
 	// Not in cache, generate and cache it
@@ -53,68 +210,399 @@ func (s *Structsql) getTableName(typ *tinyreflect.Type, tableStr *string) {
 	cachedName := c.GetString(BuffOut)
 	c.ResetBuffer(BuffOut)
 
-	// Cache the result
-	if len(s.tableNameCache) < cap(s.tableNameCache) {
-		s.tableNameCache = append(s.tableNameCache, tableNameCacheEntry{
-			typePtr:   typPtr,
-			tableName: cachedName,
-		})
+	switch s.tableNaming {
+	case PluralSimple:
+		cachedName += "s"
+	case PluralEnglish:
+		cachedName = pluralize(cachedName, s.separator)
 	}
 
+	// Cache the result, see Structsql for the cache policy
+	s.tableNameCache[typPtr] = cachedName
+
 	*tableStr = cachedName
 }
 
 func (s *Structsql) getTypeInfo(typ *tinyreflect.Type) (*typeInfo, error) {
 	typPtr := uintptr(unsafe.Pointer(typ))
-	var foundInfo *typeInfo
-
-	for _, entry := range s.typeCache {
-		if entry.typePtr == typPtr {
-			foundInfo = entry.info
-			break
-		}
-	}
+	foundInfo := s.typeCache[typPtr]
 
 	if foundInfo == nil {
 		numFields, err := typ.NumField()
 		if err != nil {
 			return nil, err
 		}
-		fields := make([]fieldInfo, numFields)
-		for i := 0; i < numFields; i++ {
-			field, err := typ.Field(i)
-			if err != nil {
-				return nil, err
+		fields := make([]fieldInfo, 0, numFields)
+		if err := s.collectFields(typ, nil, 0, &fields); err != nil {
+			return nil, err
+		}
+
+		hasTaggedPK := false
+		for i := range fields {
+			if fields[i].PK {
+				hasTaggedPK = true
+				break
+			}
+		}
+
+		// Without a pk tag, the configured PrimaryKeyNames come first, in their order
+		if !hasTaggedPK {
+			if i := primaryKeyByName(fields, s.pkNames); i != -1 {
+				fields[i].PK = true
+				hasTaggedPK = true
+			}
+		}
+
+		// Else ask the PKFunc, which replaces the naming conventions below
+		if !hasTaggedPK && s.pkFunc != nil {
+			var tableStr string
+			s.getTableName(typ, &tableStr)
+			for i := range fields {
+				if s.pkFunc(tableStr, fields[i].Name) {
+					fields[i].PK = true
+					break
+				}
 			}
-			s.convPool.WrString(BuffOut, field.Name.Name())
+		}
+
+		// Else detect the key by naming convention against the singular
+		// struct name (eg: idproduct, product_id) not the pluralized table
+		if !hasTaggedPK && s.pkFunc == nil {
+			s.convPool.WrString(BuffOut, typ.Name())
 			s.convPool.ToLower()
-			name := s.convPool.GetString(BuffOut)
+			entity := s.convPool.GetString(BuffOut)
 			s.convPool.ResetBuffer(BuffOut)
-			fields[i] = fieldInfo{Name: name}
+			for i := range fields {
+				if _, isPK := IDorPrimaryKey(entity, fields[i].Name); isPK {
+					fields[i].PK = true
+					break
+				}
+			}
 		}
-		foundInfo = &typeInfo{fields: fields}
-
-		if len(s.typeCache) < cap(s.typeCache) {
-			s.typeCache = append(s.typeCache, typeCacheEntry{typePtr: typPtr, info: foundInfo})
+		// Cache the primary key index: fields flagged by their tag or by naming
+		// convention, else a match against the table name (eg: users -> id)
+		pkIndex := -1
+		for i := range fields {
+			if fields[i].PK {
+				pkIndex = i
+				break
+			}
 		}
+		if pkIndex == -1 && s.pkFunc == nil {
+			var tableStr string
+			s.getTableName(typ, &tableStr)
+			for i := range fields {
+				if _, isPK := IDorPrimaryKey(tableStr, fields[i].Name); isPK {
+					pkIndex = i
+					break
+				}
+			}
+		}
+
+		foundInfo = &typeInfo{fields: fields, pkIndex: pkIndex}
+
+		s.typeCache[typPtr] = foundInfo
 	}
 
 	return foundInfo, nil
 }
 
-func (s *Structsql) findIdField(tableStr string, fields []fieldInfo, required bool) (int, error) {
-	idIndex := -1
-	for i, field := range fields {
-		_, isPK := IDorPrimaryKey(tableStr, field.Name)
-		if isPK {
-			idIndex = i
-			break
+// primaryKeyByName returns the index of the field whose column matches the first
+// of names present in fields, ignoring case, or -1 when none does.
+func primaryKeyByName(fields []fieldInfo, names PrimaryKeyNames) int {
+	for _, name := range names {
+		for i := range fields {
+			if equalFold(fields[i].Name, name) {
+				return i
+			}
 		}
 	}
+	return -1
+}
+
+// collectFields appends the columns of typ to fields. Fields of embedded structs
+// without an explicit db tag name are flattened as if declared in the outer struct,
+// parent holds the index path of the struct being walked.
+func (s *Structsql) collectFields(typ *tinyreflect.Type, parent []int, parentOff uintptr, fields *[]fieldInfo) error {
+	numFields, err := typ.NumField()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < numFields; i++ {
+		field, err := typ.Field(i)
+		if err != nil {
+			return err
+		}
+		name, opts := parseTag(field.Tag().Get("db"))
+		if name == "-" {
+			continue // excluded from every generated statement
+		}
+
+		path := make([]int, len(parent)+1)
+		copy(path, parent)
+		path[len(parent)] = i
+
+		if field.Embedded() && name == "" && field.Typ.Kind() == K.Struct {
+			if err := s.collectFields(field.Typ, path, parentOff+field.Off, fields); err != nil {
+				return err
+			}
+			continue
+		}
+
+		version := name == "version" || tagHasOption(opts, "version")
+		if name == "" {
+			name = s.columnName(field.Name.Name())
+		}
+
+		for j := range *fields {
+			if (*fields)[j].Name == name {
+				return Err("duplicate column", name)
+			}
+		}
+
+		// fk=users.id references the id column of the users table
+		var refTable, refColumn string
+		if fk := tagOptionValue(opts, "fk"); fk != "" {
+			dot := LastIndex(fk, ".")
+			if dot <= 0 || dot == len(fk)-1 {
+				return Err("invalid foreign key", name, fk)
+			}
+			refTable, refColumn = fk[:dot], fk[dot+1:]
+		}
+		array := tagHasOption(opts, "array")
+		if array && field.Typ.Kind() != K.Slice {
+			return Err("array option requires a slice field", name)
+		}
+		onDelete := tagOptionValue(opts, "ondelete")
+		if onDelete != "" {
+			onDelete = Convert(onDelete).ToUpper().String()
+		}
 
-	if idIndex == -1 && required {
-		return -1, Err("struct must have a primary key field")
+		*fields = append(*fields, fieldInfo{
+			Name:       name,
+			Index:      path,
+			Offset:     parentOff + field.Off,
+			Kind:       field.Typ.Kind(),
+			PK:         tagHasOption(opts, "pk"),
+			Auto:       tagHasOption(opts, "auto"),
+			Version:    version,
+			AutoCreate: tagHasOption(opts, "autocreate"),
+			AutoUpdate: tagHasOption(opts, "autoupdate"),
+			Indexed:    tagHasOption(opts, "index") || tagHasOption(opts, "unique"),
+			Unique:     tagHasOption(opts, "unique"),
+			NotNull:    tagHasOption(opts, "notnull"),
+			Default:    tagOptionValue(opts, "default"),
+			JSON:       tagHasOption(opts, "json"),
+			Array:      array,
+			SQLType:    tagOptionValue(opts, "type"),
+			RefTable:   refTable,
+			RefColumn:  refColumn,
+			OnDelete:   onDelete,
+			Typ:        field.Typ,
+		})
+	}
+	return nil
+}
+
+// columnName returns the column name of an untagged struct field following the ColumnCase
+func (s *Structsql) columnName(fieldName string) string {
+	switch s.columnCase {
+	case Snake:
+		return snakeCase(fieldName, s.separator)
+	case AsIs:
+		return fieldName
+	}
+	s.convPool.WrString(BuffOut, fieldName)
+	s.convPool.ToLower()
+	name := s.convPool.GetString(BuffOut)
+	s.convPool.ResetBuffer(BuffOut)
+	return name
+}
+
+// primaryKey returns the index in fields of the primary key detected when the
+// typeInfo was built, or ErrNoPrimaryKey if the struct has none.
+func (t *typeInfo) primaryKey() (int, error) {
+	if t.pkIndex == -1 {
+		return -1, ErrNoPrimaryKey
+	}
+	return t.pkIndex, nil
+}
+
+// parseTag splits a db struct tag into the column name and its comma separated options.
+// eg: "id,pk" returns ("id", "pk")
+func parseTag(tag string) (name, opts string) {
+	if i := Index(tag, ","); i >= 0 {
+		return tag[:i], tag[i+1:]
 	}
+	return tag, ""
+}
+
+// nextOption splits the first option off the comma separated opts. Commas inside
+// parentheses belong to the option, eg: "type=DECIMAL(10,2),notnull" returns
+// ("type=DECIMAL(10,2)", "notnull")
+func nextOption(opts string) (opt, rest string) {
+	depth := 0
+	for i := 0; i < len(opts); i++ {
+		switch opts[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth <= 0 {
+				return opts[:i], opts[i+1:]
+			}
+		}
+	}
+	return opts, ""
+}
+
+// tagHasOption reports whether the comma separated opts contain option.
+func tagHasOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts = nextOption(opts)
+		if opt == option {
+			return true
+		}
+	}
+	return false
+}
 
-	return idIndex, nil
+// tagOptionValue returns the value of a key=value option in the comma separated opts,
+// eg: tagOptionValue("notnull,default='active'", "default") returns "'active'"
+func tagOptionValue(opts, key string) string {
+	for opts != "" {
+		var opt string
+		opt, opts = nextOption(opts)
+		if len(opt) > len(key) && opt[len(key)] == '=' && opt[:len(key)] == key {
+			return opt[len(key)+1:]
+		}
+	}
+	return ""
+}
+
+// value returns the struct field described by f from the struct value val,
+// walking through embedded structs for promoted fields
+func (f *fieldInfo) value(val tinyreflect.Value) (tinyreflect.Value, error) {
+	var err error
+	for _, i := range f.Index {
+		if val, err = val.Field(i); err != nil {
+			return val, err
+		}
+	}
+	return val, nil
+}
+
+// structBase returns the address of the struct value val, or nil when the struct
+// is stored in the interface word itself and fields must be read through val.
+func structBase(val tinyreflect.Value) unsafe.Pointer {
+	if val.Kind() != K.Struct || !val.Type().IfaceIndir() {
+		return nil
+	}
+	v, err := val.Interface()
+	if err != nil {
+		return nil
+	}
+	return (*tinyreflect.EmptyInterface)(unsafe.Pointer(&v)).Data
+}
+
+// direct stores in iface the field f of the struct at base using the cached offset,
+// skipping the reflection walk. It handles fields held by address in an interface,
+// basic kinds and structs such as time.Time, and reports false for the others.
+func (f *fieldInfo) direct(base unsafe.Pointer, iface *any) bool {
+	if base == nil {
+		return false
+	}
+	switch f.Kind {
+	case K.String, K.Bool, K.Int, K.Int8, K.Int16, K.Int32, K.Int64,
+		K.Uint, K.Uint8, K.Uint16, K.Uint32, K.Uint64, K.Float32, K.Float64:
+	case K.Struct:
+		if !f.Typ.IfaceIndir() {
+			return false
+		}
+	default:
+		return false
+	}
+	e := (*tinyreflect.EmptyInterface)(unsafe.Pointer(iface))
+	e.Type = f.Typ
+	e.Data = unsafe.Add(base, f.Offset)
+	return true
+}
+
+// bindValue stores in iface the value to bind for the struct field fieldVal.
+// Pointer fields bind a copy of the pointed value and a nil pointer binds an
+// untyped nil, drivers reject typed nils but bind nil as SQL NULL for optional columns.
+// driver.Valuer fields bind their Value, byte arrays are bound as slices and
+// named basic types as their predeclared type.
+func bindValue(fieldVal tinyreflect.Value, iface *any) error {
+	if fieldVal.Kind() == K.Pointer {
+		isNil, err := fieldVal.IsNil()
+		if err != nil {
+			return err
+		}
+		if isNil {
+			*iface = nil
+			return nil
+		}
+		if fieldVal, err = fieldVal.Elem(); err != nil {
+			return err
+		}
+		fieldVal.InterfaceZeroAlloc(iface)
+		copyInterface(iface) // the pointed value belongs to the caller
+	} else {
+		fieldVal.InterfaceZeroAlloc(iface)
+	}
+	if err := bindValuer(iface); err != nil {
+		return err
+	}
+	bindBytes(iface)
+	bindBasic(iface)
+	return nil
+}
+
+// isZero reports whether the field value v holds its zero value. Unlike
+// tinyreflect IsZero it also handles nil pointer fields and struct values such as
+// time.Time columns, which are zero when all of their fields are.
+func isZero(v tinyreflect.Value) bool {
+	switch v.Kind() {
+	case K.Pointer:
+		isNil, err := v.IsNil()
+		return err == nil && isNil
+	case K.Struct:
+		numFields, err := v.NumField()
+		if err != nil {
+			return false
+		}
+		for i := 0; i < numFields; i++ {
+			field, err := v.Field(i)
+			if err != nil || !isZero(field) {
+				return false
+			}
+		}
+		return true
+	}
+	return v.IsZero()
+}
+
+// versionIndex returns the index in fields of the optimistic locking version
+// column, or -1 if the struct has none.
+func (t *typeInfo) versionIndex() int {
+	for i := range t.fields {
+		if t.fields[i].Version {
+			return i
+		}
+	}
+	return -1
+}
+
+// columnIndex returns the index in fields of the given column name, or -1 if
+// the struct has no such column. Used to validate caller supplied column names.
+func (t *typeInfo) columnIndex(column string) int {
+	for i := range t.fields {
+		if t.fields[i].Name == column {
+			return i
+		}
+	}
+	return -1
 }
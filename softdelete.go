@@ -0,0 +1,118 @@
+package structsql
+
+import (
+	"github.com/cdvelop/tinyreflect"
+	. "github.com/cdvelop/tinystring"
+)
+
+// deletedAtColumn marks a row as soft deleted when it holds a timestamp
+const deletedAtColumn = "deleted_at"
+
+type softDeleteFilter bool
+
+// ExcludeSoftDeleted passed to New makes the Select, Count and Exists verbs add
+// "deleted_at IS NULL" for structs with a deleted_at column, eg:
+//
+//	s := structsql.New(structsql.ExcludeSoftDeleted)
+//	// SELECT id, name, deleted_at FROM users WHERE deleted_at IS NULL
+const ExcludeSoftDeleted softDeleteFilter = true
+
+// SoftDelete generates UPDATE users SET deleted_at=$1 WHERE id=$2 instead of a DELETE.
+// structTable needs a deleted_at column, its value comes from the NowFunc clock.
+func (s *Structsql) SoftDelete(structTable any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	v := structTable
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	deletedIndex := info.columnIndex(deletedAtColumn)
+	if deletedIndex == -1 {
+		return Err("soft delete requires a", deletedAtColumn, "column")
+	}
+
+	// Find ID field
+	idIndex, err := s.keyIndex(info)
+	if err != nil {
+		return err
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "UPDATE ")
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " SET ")
+	s.quote(info.fields[deletedIndex].Name, c)
+	c.WrString(BuffOut, "=")
+	s.placeholder(1, c)
+	c.WrString(BuffOut, " WHERE ")
+	s.quote(info.fields[idIndex].Name, c)
+	c.WrString(BuffOut, "=")
+	s.placeholder(2, c)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	// Populate values
+	if err := s.ensureCapacity(values, 2); err != nil {
+		return err
+	}
+	*values = append(*values, s.timestampValue())
+	fieldVal, err := info.fields[idIndex].value(tinyreflect.ValueOf(v))
+	if err != nil {
+		return err
+	}
+	var iface any
+	if err := bindValue(fieldVal, &iface); err != nil {
+		return err
+	}
+	s.appendArgs(values, iface)
+
+	return nil
+}
+
+// writeNotDeleted appends the "deleted_at IS NULL" filter when ExcludeSoftDeleted is
+// configured and the struct has a deleted_at column. hasWhere tells whether the
+// statement already has a WHERE clause to extend with AND.
+func (s *Structsql) writeNotDeleted(c *Conv, info *typeInfo, hasWhere bool) {
+	s.writeNotDeletedAs(c, info, "", hasWhere)
+}
+
+// writeNotDeletedAs is writeNotDeleted with the column qualified by alias, eg: u.deleted_at
+func (s *Structsql) writeNotDeletedAs(c *Conv, info *typeInfo, alias string, hasWhere bool) {
+	if !s.excludeDeleted {
+		return
+	}
+	deletedIndex := info.columnIndex(deletedAtColumn)
+	if deletedIndex == -1 {
+		return
+	}
+	if hasWhere {
+		c.WrString(BuffOut, " AND ")
+	} else {
+		c.WrString(BuffOut, " WHERE ")
+	}
+	if alias != "" {
+		c.WrString(BuffOut, alias)
+		c.WrString(BuffOut, ".")
+	}
+	s.quote(info.fields[deletedIndex].Name, c)
+	c.WrString(BuffOut, " IS NULL")
+}
@@ -0,0 +1,110 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestSoftDelete(t *testing.T) {
+	a := Account{ID: 1, Name: "Alice"}
+	wantSQL := "UPDATE accounts SET deleted_at=$1 WHERE id=$2"
+	wantArgs := []any{fixedNow, 1}
+
+	s := structsql.New(structsql.NowFunc(fixedClock))
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.SoftDelete(a, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("SoftDelete error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("SoftDelete SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("SoftDelete args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestSoftDeleteMissingColumn(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.SoftDelete(User{ID: 1}, &gotSQL, &gotArgs); err == nil {
+		t.Fatalf("SoftDelete expected error without deleted_at column, got SQL: %s", gotSQL)
+	}
+}
+
+func TestExcludeSoftDeleted(t *testing.T) {
+	s := structsql.New(structsql.ExcludeSoftDeleted)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Select(Account{ID: 1}, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Select error: %v", err)
+	}
+	if want := "SELECT id, name, deleted_at FROM accounts WHERE id=$1 AND deleted_at IS NULL"; gotSQL != want {
+		t.Fatalf("Select SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+
+	if err := s.SelectAll(Account{}, &gotSQL); err != nil {
+		t.Fatalf("SelectAll error: %v", err)
+	}
+	if want := "SELECT id, name, deleted_at FROM accounts WHERE deleted_at IS NULL"; gotSQL != want {
+		t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+
+	// Structs without deleted_at are not filtered
+	if err := s.SelectAll(User{}, &gotSQL); err != nil {
+		t.Fatalf("SelectAll error: %v", err)
+	}
+	if want := "SELECT id, name, email FROM users"; gotSQL != want {
+		t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+}
+
+func TestExcludeSoftDeletedCounts(t *testing.T) {
+	s := structsql.New(structsql.ExcludeSoftDeleted)
+	a := Account{ID: 1, Name: "Alice"}
+
+	tests := []struct {
+		name    string
+		build   func(sql *string, args *[]any) error
+		wantSQL string
+	}{
+		{"Count", func(sql *string, args *[]any) error {
+			return s.Count(a, sql)
+		}, "SELECT COUNT(*) FROM accounts WHERE deleted_at IS NULL"},
+		{"CountBy", func(sql *string, args *[]any) error {
+			return s.CountBy(a, "name", sql, args)
+		}, "SELECT COUNT(*) FROM accounts WHERE name=$1 AND deleted_at IS NULL"},
+		{"CountWhere", func(sql *string, args *[]any) error {
+			return s.CountWhere(a, structsql.NewWhere().Eq("name", "Alice"), sql, args)
+		}, "SELECT COUNT(*) FROM accounts WHERE name=$1 AND deleted_at IS NULL"},
+		{"CountWhere empty", func(sql *string, args *[]any) error {
+			return s.CountWhere(a, nil, sql, args)
+		}, "SELECT COUNT(*) FROM accounts WHERE deleted_at IS NULL"},
+		{"Exists", func(sql *string, args *[]any) error {
+			return s.Exists(a, sql, args)
+		}, "SELECT EXISTS(SELECT 1 FROM accounts WHERE id=$1 AND deleted_at IS NULL)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := tt.build(&gotSQL, &gotArgs); err != nil {
+				t.Fatalf("%s error: %v", tt.name, err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("%s SQL mismatch:\n got: %s\nwant: %s", tt.name, gotSQL, tt.wantSQL)
+			}
+		})
+	}
+}
@@ -0,0 +1,21 @@
+package sqlx_test
+
+type User struct {
+	ID    int    `db:"id,pk"`
+	Name  string `db:"name"`
+	Email string `db:"email"`
+}
+
+func (u User) StructName() string {
+	return "User"
+}
+
+type AutoUser struct {
+	ID    int    `db:"id,pk,auto"`
+	Name  string `db:"name"`
+	Email string `db:"email"`
+}
+
+func (u AutoUser) StructName() string {
+	return "User"
+}
@@ -0,0 +1,51 @@
+// Package sqlx runs the statements generated by structsql with database/sql.
+// It is kept apart from structsql so using it only for SQL strings doesn't
+// require database/sql, eg: with TinyGo.
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cdvelop/structsql"
+)
+
+// DBExecer is implemented by *sql.DB, *sql.Tx and *sql.Conn
+type DBExecer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// DBExecerContext is implemented by *sql.DB, *sql.Tx and *sql.Conn
+type DBExecerContext interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// ExecInsert generates the INSERT for row with s.Insert and runs it on db.
+func ExecInsert(s *structsql.Structsql, db DBExecer, row any) (sql.Result, error) {
+	var query string
+	values := make([]any, 0, 16)
+
+	if err := s.Insert(row, &query, &values); err != nil {
+		return nil, err
+	}
+
+	return db.Exec(query, values...)
+}
+
+// ExecInsertContext is ExecInsert running the statement with ExecContext, so ctx
+// cancels it or bounds it with a deadline. A ctx already done returns its error
+// without building or running the statement.
+func ExecInsertContext(ctx context.Context, s *structsql.Structsql, db DBExecerContext, row any) (sql.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var query string
+	values := make([]any, 0, 16)
+
+	if err := s.Insert(row, &query, &values); err != nil {
+		return nil, err
+	}
+
+	return db.ExecContext(ctx, query, values...)
+}
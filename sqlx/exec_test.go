@@ -0,0 +1,110 @@
+package sqlx_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+	"github.com/cdvelop/structsql/sqlx"
+)
+
+// fakeExecer records the statement it receives instead of running it
+type fakeExecer struct {
+	query string
+	args  []any
+}
+
+func (f *fakeExecer) Exec(query string, args ...any) (sql.Result, error) {
+	f.query = query
+	f.args = args
+	return fakeResult{}, nil
+}
+
+func (f *fakeExecer) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return f.Exec(query, args...)
+}
+
+type fakeResult struct{}
+
+func (fakeResult) LastInsertId() (int64, error) { return 1, nil }
+func (fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+func TestExecInsert(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)"
+	wantArgs := []any{1, "Alice", "alice@example.com"}
+
+	s := structsql.New()
+	db := &fakeExecer{}
+
+	res, err := sqlx.ExecInsert(s, db, u)
+	if err != nil {
+		t.Fatalf("ExecInsert error: %v", err)
+	}
+	if n, _ := res.RowsAffected(); n != 1 {
+		t.Fatalf("ExecInsert result mismatch: %d rows affected", n)
+	}
+
+	if db.query != wantSQL {
+		t.Fatalf("ExecInsert SQL mismatch:\n got: %s\nwant: %s", db.query, wantSQL)
+	}
+
+	if !reflect.DeepEqual(db.args, wantArgs) {
+		t.Fatalf("ExecInsert args mismatch:\n got: %v\nwant: %v", db.args, wantArgs)
+	}
+}
+
+func TestExecInsertError(t *testing.T) {
+	s := structsql.New()
+	db := &fakeExecer{}
+
+	if _, err := sqlx.ExecInsert(s, db, nil); err == nil {
+		t.Fatal("ExecInsert expected error for nil row, got nil")
+	}
+	if db.query != "" {
+		t.Fatalf("ExecInsert ran a statement after a build error: %s", db.query)
+	}
+}
+
+func TestExecInsertContext(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)"
+	wantArgs := []any{1, "Alice", "alice@example.com"}
+
+	s := structsql.New()
+	db := &fakeExecer{}
+
+	if _, err := sqlx.ExecInsertContext(context.Background(), s, db, u); err != nil {
+		t.Fatalf("ExecInsertContext error: %v", err)
+	}
+
+	if db.query != wantSQL {
+		t.Fatalf("ExecInsertContext SQL mismatch:\n got: %s\nwant: %s", db.query, wantSQL)
+	}
+
+	if !reflect.DeepEqual(db.args, wantArgs) {
+		t.Fatalf("ExecInsertContext args mismatch:\n got: %v\nwant: %v", db.args, wantArgs)
+	}
+}
+
+func TestExecInsertContextCanceled(t *testing.T) {
+	s := structsql.New()
+	db := &fakeExecer{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := sqlx.ExecInsertContext(ctx, s, db, User{ID: 1})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ExecInsertContext error = %v, want context.Canceled", err)
+	}
+	if db.query != "" {
+		t.Fatalf("ExecInsertContext ran a statement with a canceled context: %s", db.query)
+	}
+}
@@ -0,0 +1,25 @@
+package sqlx
+
+import (
+	"database/sql"
+
+	"github.com/cdvelop/structsql"
+)
+
+// InsertNamed generates INSERT INTO users (name, email) VALUES (:name, :email) with
+// s.InsertNamed and fills args with one sql.NamedArg per column.
+func InsertNamed(s *structsql.Structsql, row any, query *string, args *[]sql.NamedArg) error {
+	var names []string
+	values := make([]any, 0, 16)
+
+	if err := s.InsertNamed(row, query, &names, &values); err != nil {
+		return err
+	}
+
+	*args = (*args)[:0]
+	for i, name := range names {
+		*args = append(*args, sql.Named(name, values[i]))
+	}
+
+	return nil
+}
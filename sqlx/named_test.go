@@ -0,0 +1,36 @@
+package sqlx_test
+
+import (
+	"database/sql"
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+	"github.com/cdvelop/structsql/sqlx"
+)
+
+func TestInsertNamed(t *testing.T) {
+	u := AutoUser{Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (name, email) VALUES (:name, :email)"
+	wantArgs := []sql.NamedArg{
+		sql.Named("name", "Alice"),
+		sql.Named("email", "alice@example.com"),
+	}
+
+	s := structsql.New()
+	var gotSQL string
+	var gotArgs []sql.NamedArg
+
+	err := sqlx.InsertNamed(s, u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("InsertNamed error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("InsertNamed SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("InsertNamed args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
@@ -0,0 +1,104 @@
+package sqlx
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"sync"
+	"unsafe"
+
+	"github.com/cdvelop/structsql"
+)
+
+// DBPreparer is implemented by *sql.DB and *sql.Tx. Statements keys its cache on
+// the identity of the pointer, implementations of other kinds are rejected.
+type DBPreparer interface {
+	Prepare(query string) (*sql.Stmt, error)
+}
+
+// Statements caches the statements prepared for the SQL generated by a Structsql.
+// A statement belongs to the database it was prepared on, so entries are keyed by
+// the database pointer and SQL. It is safe for concurrent use.
+type Statements struct {
+	s     *structsql.Structsql
+	mu    sync.Mutex
+	byKey map[stmtKey]*sql.Stmt
+}
+
+// stmtKey identifies db by its type and address instead of the interface value,
+// which would panic as a map key for a non comparable implementation
+type stmtKey struct {
+	typ reflect.Type
+	db  unsafe.Pointer
+	sql string
+}
+
+// ErrNotPointer is returned by PrepareInsert for a DBPreparer that isn't a pointer
+var ErrNotPointer = errors.New("sqlx: DBPreparer must be a pointer")
+
+// NewStatements returns an empty cache preparing the SQL generated by s
+func NewStatements(s *structsql.Structsql) *Statements {
+	return &Statements{s: s, byKey: make(map[stmtKey]*sql.Stmt, 16)}
+}
+
+// PrepareInsert prepares the INSERT generated for structTable on db and caches it,
+// the generated SQL is stable for a type so later calls return the same statement.
+// Run it with the values of a row from Insert. Statements stay open until Close,
+// a *sql.Tx statement must not be used after the transaction ends.
+func (st *Statements) PrepareInsert(db DBPreparer, structTable any) (*sql.Stmt, error) {
+	dbVal := reflect.ValueOf(db)
+	if dbVal.Kind() != reflect.Pointer {
+		return nil, ErrNotPointer
+	}
+
+	var query string
+	values := make([]any, 0, 16)
+
+	if err := st.s.Insert(structTable, &query, &values); err != nil {
+		return nil, err
+	}
+
+	key := stmtKey{typ: dbVal.Type(), db: dbVal.UnsafePointer(), sql: query}
+
+	st.mu.Lock()
+	stmt, ok := st.byKey[key]
+	st.mu.Unlock()
+	if ok {
+		return stmt, nil
+	}
+
+	// Prepare outside the lock, it is a round trip to the database
+	stmt, err := db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	if cached, ok := st.byKey[key]; ok {
+		// Another goroutine prepared it meanwhile
+		stmt.Close()
+		return cached, nil
+	}
+	st.byKey[key] = stmt
+
+	return stmt, nil
+}
+
+// Close closes every statement prepared by PrepareInsert and empties the cache,
+// returning the errors of the statements that failed to close.
+func (st *Statements) Close() error {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+
+	var errs []error
+	for key, stmt := range st.byKey {
+		if err := stmt.Close(); err != nil {
+			errs = append(errs, err)
+		}
+		delete(st.byKey, key)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,119 @@
+package sqlx_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+	"github.com/cdvelop/structsql/sqlx"
+)
+
+// countingDriver is a database/sql driver that counts prepared and closed statements
+type countingDriver struct {
+	prepared []string
+	closed   int
+}
+
+func (d *countingDriver) Open(name string) (driver.Conn, error) { return countingConn{d}, nil }
+
+// Connect and Driver make countingDriver a connector for sql.OpenDB
+func (d *countingDriver) Connect(context.Context) (driver.Conn, error) { return countingConn{d}, nil }
+func (d *countingDriver) Driver() driver.Driver                        { return d }
+
+type countingConn struct{ d *countingDriver }
+
+func (c countingConn) Prepare(query string) (driver.Stmt, error) {
+	c.d.prepared = append(c.d.prepared, query)
+	return countingStmt{c.d}, nil
+}
+func (c countingConn) Close() error              { return nil }
+func (c countingConn) Begin() (driver.Tx, error) { return nil, driver.ErrSkip }
+
+type countingStmt struct{ d *countingDriver }
+
+func (s countingStmt) Close() error  { s.d.closed++; return nil }
+func (s countingStmt) NumInput() int { return -1 }
+func (s countingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+func (s countingStmt) Query(args []driver.Value) (driver.Rows, error) { return nil, driver.ErrSkip }
+
+func TestPrepareInsert(t *testing.T) {
+	drv := &countingDriver{}
+	db := sql.OpenDB(drv)
+	defer db.Close()
+
+	s := structsql.New()
+	stmts := sqlx.NewStatements(s)
+	users := []User{
+		{ID: 1, Name: "Alice", Email: "alice@example.com"},
+		{ID: 2, Name: "Bob", Email: "bob@example.com"},
+		{ID: 3, Name: "Carol", Email: "carol@example.com"},
+	}
+
+	var first *sql.Stmt
+	for _, u := range users {
+		stmt, err := stmts.PrepareInsert(db, u)
+		if err != nil {
+			t.Fatalf("PrepareInsert error: %v", err)
+		}
+		if first == nil {
+			first = stmt
+		} else if stmt != first {
+			t.Fatal("PrepareInsert returned a new statement for the same type")
+		}
+
+		var query string
+		values := make([]any, 0, 10)
+		if err := s.Insert(u, &query, &values); err != nil {
+			t.Fatalf("Insert error: %v", err)
+		}
+		if _, err := stmt.Exec(values...); err != nil {
+			t.Fatalf("Exec error: %v", err)
+		}
+	}
+
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)"
+	if len(drv.prepared) != 1 || drv.prepared[0] != wantSQL {
+		t.Fatalf("prepared statements mismatch:\n got: %q\nwant: [%q]", drv.prepared, wantSQL)
+	}
+
+	if err := stmts.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+	if drv.closed != 1 {
+		t.Fatalf("Close closed %d driver statements, want 1", drv.closed)
+	}
+
+	if _, err := stmts.PrepareInsert(db, users[0]); err != nil {
+		t.Fatalf("PrepareInsert error: %v", err)
+	}
+	if len(drv.prepared) != 2 {
+		t.Fatalf("PrepareInsert after Close prepared %d times, want 2", len(drv.prepared))
+	}
+}
+
+// funcPreparer is a non comparable DBPreparer
+type funcPreparer func(query string) (*sql.Stmt, error)
+
+func (f funcPreparer) Prepare(query string) (*sql.Stmt, error) { return f(query) }
+
+func TestPrepareInsertNotPointer(t *testing.T) {
+	drv := &countingDriver{}
+	db := sql.OpenDB(drv)
+	defer db.Close()
+
+	stmts := sqlx.NewStatements(structsql.New())
+	defer stmts.Close()
+
+	_, err := stmts.PrepareInsert(funcPreparer(db.Prepare), User{ID: 1})
+	if !errors.Is(err, sqlx.ErrNotPointer) {
+		t.Fatalf("PrepareInsert error = %v, want ErrNotPointer", err)
+	}
+	if len(drv.prepared) != 0 {
+		t.Fatalf("PrepareInsert prepared %d statements for a rejected database", len(drv.prepared))
+	}
+}
@@ -1,6 +1,10 @@
 package structsql
 
 import (
+	"sync"
+	"time"
+
+	"github.com/cdvelop/tinyreflect"
 	. "github.com/cdvelop/tinystring"
 )
 
@@ -11,6 +15,8 @@ type dbType string
 const (
 	PostgreSQL dbType = "postgres"
 	SQLite     dbType = "sqlite"
+	MySQL      dbType = "mysql"
+	SQLServer  dbType = "sqlserver"
 )
 
 // placeholder generates the appropriate placeholder for the database type
@@ -20,41 +26,268 @@ func (d dbType) placeholder(index int, conv *Conv) {
 		placeholderPostgre(index, conv)
 	case SQLite:
 		placeholderSQLite(index, conv)
+	case MySQL:
+		placeholderMySQL(index, conv)
+	case SQLServer:
+		placeholderSQLServer(index, conv)
+	default:
+		if reg, ok := lookupDialect(d); ok && reg.placeholder != nil {
+			reg.placeholder(index, conv)
+		}
 	}
 }
 
+// PlaceholderStart passed to New numbers placeholders from the given index instead
+// of 1, to embed the generated SQL in a larger query with its own parameters, eg:
+// PlaceholderStart(5) generates UPDATE users SET name=$5 WHERE id=$6
+type PlaceholderStart int
+
+// PrimaryKeyNames passed to New lists column names detected as the primary key of
+// structs without a pk tag, eg: PrimaryKeyNames{"uuid", "pk"}. The first name found
+// in the struct wins, compared ignoring case, before the id naming conventions.
+type PrimaryKeyNames []string
+
+// PKFunc passed to New replaces the id naming conventions of IDorPrimaryKey for
+// structs without a pk tag, eg: to key tables on oid or rowid. It is called with
+// the table name and each column in field order, the first column it accepts is
+// the primary key. PrimaryKeyNames are still tried first.
+type PKFunc func(table, column string) (isPK bool)
+
+// placeholder writes the placeholder of the 1-based parameter index shifted by
+// the configured PlaceholderStart
+func (s *Structsql) placeholder(index int, conv *Conv) {
+	s.dbType.placeholder(index+s.placeholderOffset, conv)
+}
+
+// writeIndex writes a placeholder index into BuffOut.
+// Avoids AnyToBuff, which boxes every index above 255 and allocates in large batches.
+func writeIndex(index int, conv *Conv) {
+	var buf [20]byte
+	i := len(buf)
+	for {
+		i--
+		buf[i] = byte('0' + index%10)
+		index /= 10
+		if index == 0 {
+			break
+		}
+	}
+	conv.WrString(BuffOut, string(buf[i:]))
+}
+
+// quote writes a table or column name wrapped in the identifier quotes of the database type
+func (d dbType) quote(name string, conv *Conv) {
+	switch d {
+	case PostgreSQL:
+		quotePostgre(name, conv)
+	case SQLite:
+		quoteSQLite(name, conv)
+	case MySQL:
+		quoteMySQL(name, conv)
+	case SQLServer:
+		quoteSQLServer(name, conv)
+	default:
+		if reg, ok := lookupDialect(d); ok && reg.quote != nil {
+			reg.quote(name, conv)
+			return
+		}
+		conv.WrString(BuffOut, name)
+	}
+}
+
+// quoteTable writes a table name qualified by the configured Schema, eg: app.users
+func (s *Structsql) quoteTable(table string, conv *Conv) {
+	if s.stmtTable == "" {
+		s.stmtTable = table // first table of the statement, for Annotate
+	}
+	if s.schema != "" {
+		s.quote(string(s.schema), conv)
+		conv.WrString(BuffOut, ".")
+	}
+	s.quote(table, conv)
+}
+
+// Schema passed to New qualifies every table name, eg: Schema("app") generates app.users
+// The schema is written verbatim, WordSeparator and TableNaming don't apply to it.
+type Schema string
+
+// TableNamer overrides the table name derived from the struct name, eg: User -> app_users.
+// The name is read once per type and cached, so it must not depend on the value.
+// TableName must have a value receiver so every call finds it whether the struct is
+// passed by value or by pointer, a pointer receiver returns ErrPointerTableNamer.
+type TableNamer interface {
+	TableName() string
+}
+
+// TableNaming selects how struct names are turned into table names
+type TableNaming string
+
+// Table naming strategies, PluralEnglish is the default
+const (
+	Singular      TableNaming = "singular"       // User -> user
+	PluralSimple  TableNaming = "plural_simple"  // User -> users, Box -> boxs
+	PluralEnglish TableNaming = "plural_english" // Person -> people, Box -> boxes
+)
+
 type fieldInfo struct {
-	Name string
+	Name       string            // column name, from the db tag or the lowercased field name
+	Index      []int             // struct field index path, longer than one for fields promoted from embedded structs
+	Offset     uintptr           // byte offset from the start of the struct, embedded structs included
+	Kind       Kind              // kind of Typ, selects the direct read path, see fieldInfo.direct
+	PK         bool              // tagged with the pk option or detected by naming convention
+	Auto       bool              // tagged with the auto option, value generated by the database
+	Version    bool              // tagged db:"version" or with the version option, optimistic locking counter
+	AutoCreate bool              // tagged with the autocreate option, set to the current time by Insert
+	AutoUpdate bool              // tagged with the autoupdate option, set to the current time by Insert and Update
+	Indexed    bool              // tagged with the index or unique option, see CreateIndexes
+	Unique     bool              // tagged with the unique option
+	NotNull    bool              // tagged with the notnull option, CreateTable adds NOT NULL
+	Default    string            // raw SQL of the default= option, eg: db:"status,default='active'"
+	JSON       bool              // tagged with the json option, bound as a JSON string, see JSONMarshal
+	Array      bool              // slice tagged with the array option, a PostgreSQL array, see bindArray
+	SQLType    string            // raw SQL of the type= option, replaces the column type in CreateTable
+	RefTable   string            // table of the fk= option, eg: users for db:"user_id,fk=users.id"
+	RefColumn  string            // column of the fk= option, eg: id
+	OnDelete   string            // uppercased action of the ondelete= option, eg: CASCADE
+	Typ        *tinyreflect.Type // Go type of the field, used for DDL column types
 }
 
 type typeInfo struct {
-	fields []fieldInfo
+	fields  []fieldInfo
+	pkIndex int // index in fields of the primary key, -1 when there is none
 }
 
-type tableNameCacheEntry struct {
-	typePtr   uintptr
-	tableName string
+// Structsql is safe for concurrent use. Every method holds mu while it uses the
+// shared Conv and caches, and the SQL handed back to callers is interned so it is
+// never overwritten by a later call.
+//
+// The type and table name caches grow with every distinct struct type and are
+// never evicted: an application has a fixed set of models, so they stay small and
+// each type is analysed only once. Instances derived with WithDialect share the
+// caches and therefore mu.
+type Structsql struct {
+	mu                *sync.Mutex
+	typeCache         map[uintptr]*typeInfo // analysed struct types by type pointer
+	builtCache        map[builtKey]string   // statements depending only on type, verb and database type, see cachedBuilt
+	tableNameCache    map[uintptr]string    // table names by type pointer
+	convPool          *Conv
+	dbType            dbType
+	tableNaming       TableNaming
+	sqlCache          map[string]string // interned generated SQL, see setSQL
+	now               NowFunc           // clock for autocreate and autoupdate columns
+	excludeDeleted    bool              // set by ExcludeSoftDeleted
+	schema            Schema            // table qualifier, empty for none
+	quoteMode         QuoteMode         // empty for the database type default
+	columnCase        ColumnCase        // naming of untagged fields
+	timeFormat        TimeFormat        // layout for time.Time values, empty to bind them as is
+	strictCapacity    bool              // set by StrictCapacity
+	allowFullDelete   bool              // set by AllowFullTableDelete
+	rowID             bool              // set by SQLiteRowID
+	sqliteReturning   bool              // set by SQLiteReturning
+	placeholderOffset int               // PlaceholderStart minus one
+	boolAsInt         bool              // set by BoolAsInt
+	annotate          bool              // set by Annotate
+	separator         string            // WordSeparator, "_" by default
+	pkNames           PrimaryKeyNames   // conventional primary key columns, see getTypeInfo
+	pkFunc            PKFunc            // replaces the id naming conventions, see getTypeInfo
+	stmtTable         string            // table of the statement being built, see setSQL
+	hook              SQLHook           // rewrites generated statements, see SQLHook
+	pendingKey        builtKey          // key of the statement being built, see cachedBuilt
+	jsonMarshal       JSONMarshal       // encodes json fields, see JSONMarshal
 }
 
-type Structsql struct {
-	typeCache      []typeCacheEntry
-	tableNameCache []tableNameCacheEntry
-	convPool       *Conv
-	dbType         dbType
+// New returns a Structsql configured by configs, unrecognized configs are
+// ignored, use NewErr to reject them.
+func New(configs ...any) *Structsql {
+	s, _ := newStructsql(configs)
+	return s
 }
 
-type typeCacheEntry struct {
-	typePtr uintptr
-	info    *typeInfo
+// NewErr is like New but returns ErrUnknownConfig for a config it doesn't
+// recognize, eg: New(SQLite, "oops").
+func NewErr(configs ...any) (*Structsql, error) {
+	s, err := newStructsql(configs)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
 }
 
-func New(configs ...any) *Structsql {
-	db := PostgreSQL // Default to PostgreSQL
+// newStructsql applies configs in order, the returned error reports the first
+// unrecognized config.
+func newStructsql(configs []any) (*Structsql, error) {
+	db := PostgreSQL             // Default to PostgreSQL
+	tableNaming := PluralEnglish // Default to English plurals
+	now := NowFunc(time.Now)
+	excludeDeleted := false
+	var schema Schema
+	var quoteMode QuoteMode
+	columnCase := Lower // Default to lowercased field names
+	var timeFormat TimeFormat
+	strict := false
+	allowFullDelete := false
+	rowID := false
+	returning := false
+	placeholderOffset := 0
+	boolInt := false
+	annotated := false
+	separator := "_"
+	var pkNames PrimaryKeyNames
+	var hook SQLHook
+	var pkFunc PKFunc
+	var jsonMarshal JSONMarshal
+	var err error
 
 	// Parse configurations
-	if len(configs) > 0 {
-		if dt, ok := configs[0].(dbType); ok {
-			db = dt
+	for _, config := range configs {
+		switch v := config.(type) {
+		case dbType:
+			db = v
+		case TableNaming:
+			tableNaming = v
+		case NowFunc:
+			now = v
+		case softDeleteFilter:
+			excludeDeleted = bool(v)
+		case Schema:
+			schema = v
+		case QuoteMode:
+			quoteMode = v
+		case ColumnCase:
+			columnCase = v
+		case TimeFormat:
+			timeFormat = v
+		case strictCapacity:
+			strict = bool(v)
+		case fullTableDelete:
+			allowFullDelete = bool(v)
+		case sqliteRowID:
+			rowID = bool(v)
+		case sqliteReturning:
+			returning = bool(v)
+		case PrimaryKeyNames:
+			pkNames = v
+		case WordSeparator:
+			separator = string(v)
+		case annotate:
+			annotated = bool(v)
+		case boolAsInt:
+			boolInt = bool(v)
+		case SQLHook:
+			hook = v
+		case PKFunc:
+			pkFunc = v
+		case JSONMarshal:
+			jsonMarshal = v
+		case PlaceholderStart:
+			if v > 1 {
+				placeholderOffset = int(v) - 1
+			}
+		default:
+			if err == nil {
+				err = errDetail(ErrUnknownConfig, configName(config))
+			}
 		}
 	}
 
@@ -62,11 +295,83 @@ func New(configs ...any) *Structsql {
 	conv := GetConv()
 
 	s := &Structsql{
-		typeCache:      make([]typeCacheEntry, 0, 16),     // Pre-allocate capacity
-		tableNameCache: make([]tableNameCacheEntry, 0, 8), // Pre-allocate for table names
-		convPool:       conv,                              // Single Conv instance per Structsql
-		dbType:         db,
+		mu:                new(sync.Mutex),
+		typeCache:         make(map[uintptr]*typeInfo, 16), // Pre-allocate capacity
+		builtCache:        make(map[builtKey]string, 16),
+		tableNameCache:    make(map[uintptr]string, 16), // Pre-allocate for table names
+		convPool:          conv,                         // Single Conv instance per Structsql
+		dbType:            db,
+		tableNaming:       tableNaming,
+		sqlCache:          make(map[string]string, 16),
+		now:               now,
+		excludeDeleted:    excludeDeleted,
+		schema:            schema,
+		quoteMode:         quoteMode,
+		columnCase:        columnCase,
+		timeFormat:        timeFormat,
+		strictCapacity:    strict,
+		allowFullDelete:   allowFullDelete,
+		rowID:             rowID,
+		sqliteReturning:   returning,
+		placeholderOffset: placeholderOffset,
+		boolAsInt:         boolInt,
+		annotate:          annotated,
+		separator:         separator,
+		pkNames:           pkNames,
+		hook:              hook,
+		pkFunc:            pkFunc,
+		jsonMarshal:       jsonMarshal,
 	}
 
-	return s
+	return s, err
+}
+
+// configName names the type of an unrecognized config for ErrUnknownConfig
+func configName(config any) string {
+	if config == nil {
+		return "nil"
+	}
+	return tinyreflect.TypeOf(config).Name()
+}
+
+// Close returns the instance Conv to the tinystring pool. Any method called after
+// Close returns an error; the instance must not be reused. Calling Close twice is safe.
+// SQL strings already returned remain valid.
+func (s *Structsql) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.convPool != nil {
+		s.convPool.PutConv()
+		s.convPool = nil
+	}
+	return nil
+}
+
+// ResetCaches empties the analysed types and table names so the next call analyses
+// every struct again, eg: in a code generator reloading changed definitions. The
+// caches keep their memory and the instance keeps its Conv. Instances derived with
+// WithDialect share the caches and are reset too. It holds mu like every method,
+// but a verb racing with it may run against the definitions cached before.
+func (s *Structsql) ResetCaches() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clear(s.typeCache)
+	clear(s.tableNameCache)
+	clear(s.builtCache)
+}
+
+// WithDialect returns an instance generating SQL for db with the same configuration.
+// It shares the analysed types and table names of s, which don't depend on the
+// database type, and gets its own Conv, so it must be closed separately.
+func (s *Structsql) WithDialect(db dbType) *Structsql {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	clone := *s
+	clone.dbType = db
+	clone.convPool = GetConv()
+	clone.sqlCache = make(map[string]string, 16)
+	return &clone
 }
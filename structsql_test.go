@@ -0,0 +1,466 @@
+package structsql_test
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestConcurrentInsert(t *testing.T) {
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)"
+
+	s := structsql.New()
+	var wg sync.WaitGroup
+	errs := make(chan string, 50)
+
+	for g := 0; g < 50; g++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			u := User{ID: id, Name: "Alice", Email: "alice@example.com"}
+			wantArgs := []any{id, "Alice", "alice@example.com"}
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			for i := 0; i < 100; i++ {
+				gotArgs = gotArgs[:0]
+				if err := s.Insert(u, &gotSQL, &gotArgs); err != nil {
+					errs <- err.Error()
+					return
+				}
+				if gotSQL != wantSQL {
+					errs <- "SQL mismatch: " + gotSQL
+					return
+				}
+				if !reflect.DeepEqual(gotArgs, wantArgs) {
+					errs <- "args mismatch"
+					return
+				}
+			}
+		}(g)
+	}
+
+	wg.Wait()
+	close(errs)
+	for e := range errs {
+		t.Fatalf("concurrent Insert: %s", e)
+	}
+}
+
+func TestSQLStableAcrossCalls(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)"
+
+	s := structsql.New()
+	var insertSQL, deleteSQL string
+	args := make([]any, 0, 10)
+
+	if err := s.Insert(u, &insertSQL, &args); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	if err := s.Delete(u, &deleteSQL, &args); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+
+	if insertSQL != wantSQL {
+		t.Fatalf("Insert SQL overwritten by a later call:\n got: %s\nwant: %s", insertSQL, wantSQL)
+	}
+}
+
+func TestClose(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Insert(u, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("second Close error: %v", err)
+	}
+
+	err := s.Insert(u, &gotSQL, &gotArgs)
+	if err == nil {
+		t.Fatal("Insert after Close expected error, got nil")
+	}
+	if err.Error() != "structsql closed" {
+		t.Fatalf("Insert after Close error mismatch:\n got: %s\nwant: structsql closed", err.Error())
+	}
+}
+
+func TestResetCaches(t *testing.T) {
+	l := Ledger{ID: 1, Name: "cash"}
+	wantSQL := "INSERT INTO books (id, name) VALUES ($1, $2)"
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	ledgerTableNames = 0
+	for i := 0; i < 2; i++ {
+		gotArgs = gotArgs[:0]
+		if err := s.Insert(l, &gotSQL, &gotArgs); err != nil {
+			t.Fatalf("Insert error: %v", err)
+		}
+	}
+	if ledgerTableNames != 1 {
+		t.Fatalf("TableName called %d times before ResetCaches, want 1", ledgerTableNames)
+	}
+
+	s.ResetCaches()
+
+	gotArgs = gotArgs[:0]
+	if err := s.Insert(l, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert after ResetCaches error: %v", err)
+	}
+	if ledgerTableNames != 2 {
+		t.Fatalf("TableName called %d times after ResetCaches, want 2", ledgerTableNames)
+	}
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "/* structsql:insert users */ INSERT INTO users (id, name, email) VALUES ($1, $2, $3)"
+
+	s := structsql.New(structsql.Annotate)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Insert(u, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	wantSQL = "/* structsql:select users */ SELECT id, name, email FROM users"
+	if err := s.SelectAll(u, &gotSQL); err != nil {
+		t.Fatalf("SelectAll error: %v", err)
+	}
+	if gotSQL != wantSQL {
+		t.Fatalf("SelectAll SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestPrimaryKeyNames(t *testing.T) {
+	a := Asset{UUID: "a1", Name: "laptop"}
+	wantSQL := "UPDATE assets SET name=$1 WHERE uuid=$2"
+	wantArgs := []any{"laptop", "a1"}
+
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := structsql.New().Update(a, &gotSQL, &gotArgs); !errors.Is(err, structsql.ErrNoPrimaryKey) {
+		t.Fatalf("Update without PrimaryKeyNames error = %v, want ErrNoPrimaryKey", err)
+	}
+
+	s := structsql.New(structsql.PrimaryKeyNames{"pk", "uuid"})
+	if err := s.Update(a, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestPKFunc(t *testing.T) {
+	c := Country{ID: 7, Code: "CL", Name: "Chile"}
+	wantSQL := "UPDATE countries SET id=$1, name=$2 WHERE code=$3"
+	wantArgs := []any{7, "Chile", "CL"}
+
+	var tables []string
+	s := structsql.New(structsql.PKFunc(func(table, column string) bool {
+		tables = append(tables, table)
+		return column == "code"
+	}))
+
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+	if err := s.Update(c, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	if len(tables) == 0 || tables[0] != "countries" {
+		t.Fatalf("PKFunc tables = %v, want countries", tables)
+	}
+
+	// Without the PKFunc the id convention applies
+	if err := structsql.New().Update(c, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+	if want := "UPDATE countries SET code=$1, name=$2 WHERE id=$3"; gotSQL != want {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+}
+
+func TestNewWith(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfg      structsql.Config
+		row      any
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"naming", structsql.Config{Dialect: structsql.SQLite, TableNaming: structsql.Singular, ColumnCase: structsql.Snake,
+			Schema: "app", Annotate: true, BoolAsInt: true},
+			Feature{ID: 1, Name: "beta", Active: true},
+			"/* structsql:insert feature */ INSERT INTO app.feature (id, name, active) VALUES (?, ?, ?)",
+			[]any{1, "beta", 1}},
+		{"placeholders and clock", structsql.Config{PlaceholderStart: 3, NowFunc: fixedClock},
+			Post{ID: 1, Title: "Hello"},
+			"INSERT INTO posts (id, title, created_at, updated_at) VALUES ($3, $4, $5, $6)",
+			[]any{1, "Hello", fixedNow, fixedNow}},
+		{"defaults", structsql.Config{},
+			Person{ID: 1, Name: "Alice"},
+			"INSERT INTO people (id, name) VALUES ($1, $2)",
+			[]any{1, "Alice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.NewWith(tt.cfg)
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := s.Insert(tt.row, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("Insert error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestNewErr(t *testing.T) {
+	s, err := structsql.NewErr(structsql.SQLite, structsql.Schema("app"))
+	if err != nil {
+		t.Fatalf("NewErr error: %v", err)
+	}
+	defer s.Close()
+
+	var gotSQL string
+	args := make([]any, 0, 10)
+	if err := s.Insert(Person{ID: 1, Name: "Alice"}, &gotSQL, &args); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	if want := "INSERT INTO app.people (id, name) VALUES (?, ?)"; gotSQL != want {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+
+	s, err = structsql.NewErr(structsql.SQLite, "oops")
+	if !errors.Is(err, structsql.ErrUnknownConfig) {
+		t.Fatalf("NewErr error = %v, want ErrUnknownConfig", err)
+	}
+	if s != nil {
+		t.Fatal("NewErr returned an instance with an error")
+	}
+
+	// New keeps ignoring unrecognized configs
+	if structsql.New(structsql.SQLite, "oops") == nil {
+		t.Fatal("New returned nil")
+	}
+}
+
+func TestWithDialect(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+
+	pg := structsql.New(structsql.Schema("app"))
+	lite := pg.WithDialect(structsql.SQLite)
+	defer lite.Close()
+
+	var pgSQL, liteSQL string
+	args := make([]any, 0, 10)
+
+	if err := pg.Insert(u, &pgSQL, &args); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	if err := lite.Insert(u, &liteSQL, &args); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if want := `INSERT INTO app.users (id, name, email) VALUES ($1, $2, $3)`; pgSQL != want {
+		t.Fatalf("PostgreSQL SQL mismatch:\n got: %s\nwant: %s", pgSQL, want)
+	}
+	if want := `INSERT INTO app.users (id, name, email) VALUES (?, ?, ?)`; liteSQL != want {
+		t.Fatalf("SQLite SQL mismatch:\n got: %s\nwant: %s", liteSQL, want)
+	}
+
+	// Both instances analyse new types into the shared caches concurrently
+	var wg sync.WaitGroup
+	for _, s := range []*structsql.Structsql{pg, lite} {
+		wg.Add(1)
+		go func(s *structsql.Structsql) {
+			defer wg.Done()
+			var sql string
+			for i := 0; i < 50; i++ {
+				_ = s.SelectAll(Product{}, &sql)
+				_ = s.SelectAll(Person{}, &sql)
+			}
+		}(s)
+	}
+	wg.Wait()
+
+	// Closing the derived instance leaves the original usable
+	if err := lite.Close(); err != nil {
+		t.Fatalf("Close error: %v", err)
+	}
+	if err := pg.Insert(u, &pgSQL, &args); err != nil {
+		t.Fatalf("Insert after closing derived instance error: %v", err)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+
+	s := structsql.New(structsql.Schema("app"))
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Insert(u, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	if want := "INSERT INTO app.users (id, name, email) VALUES ($1, $2, $3)"; gotSQL != want {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+
+	if err := s.Select(u, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Select error: %v", err)
+	}
+	if want := "SELECT id, name, email FROM app.users WHERE id=$1"; gotSQL != want {
+		t.Fatalf("Select SQL mismatch:\n got: %s\nwant: %s", gotSQL, want)
+	}
+}
+
+func TestSchemaSQLServer(t *testing.T) {
+	wantSQL := "DELETE FROM [audit].[users] WHERE [id]=@p1"
+
+	s := structsql.New(structsql.SQLServer, structsql.Schema("audit"))
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Delete(User{ID: 1}, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Delete error: %v", err)
+	}
+	if gotSQL != wantSQL {
+		t.Fatalf("Delete SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestQuoteReservedWords(t *testing.T) {
+	k := Keyword{ID: 1, Order: "asc"}
+
+	tests := []struct {
+		name    string
+		configs []any
+		wantSQL string
+	}{
+		{"postgres", nil, `UPDATE keywords SET "order"=$1 WHERE id=$2`},
+		{"sqlite", []any{structsql.SQLite}, `UPDATE keywords SET "order"=? WHERE id=?`},
+		{"mysql", []any{structsql.MySQL}, "UPDATE `keywords` SET `order`=? WHERE `id`=?"},
+		{"sqlserver", []any{structsql.SQLServer}, "UPDATE [keywords] SET [order]=@p1 WHERE [id]=@p2"},
+		{"always", []any{structsql.QuoteAlways}, `UPDATE "keywords" SET "order"=$1 WHERE "id"=$2`},
+		{"mysql reserved only", []any{structsql.MySQL, structsql.QuoteReserved}, "UPDATE keywords SET `order`=? WHERE id=?"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.configs...)
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := s.Update(k, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("Update error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestQuoteReservedAsIs(t *testing.T) {
+	c := Clause{ID: 1, Order: "asc", When: "now"}
+
+	tests := []struct {
+		name    string
+		configs []any
+		wantSQL string
+	}{
+		{"postgres", []any{structsql.AsIs}, `INSERT INTO clauses ("ID", "Order", "When") VALUES ($1, $2, $3)`},
+		{"sqlite", []any{structsql.AsIs, structsql.SQLite}, `INSERT INTO clauses (ID, "Order", "When") VALUES (?, ?, ?)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := structsql.New(tt.configs...)
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := s.Insert(c, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("Insert error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestTypeCacheManyTypes(t *testing.T) {
+	rows := []any{
+		Item[[1]byte]{}, Item[[2]byte]{}, Item[[3]byte]{}, Item[[4]byte]{}, Item[[5]byte]{},
+		Item[[6]byte]{}, Item[[7]byte]{}, Item[[8]byte]{}, Item[[9]byte]{}, Item[[10]byte]{},
+		Item[[11]byte]{}, Item[[12]byte]{}, Item[[13]byte]{}, Item[[14]byte]{}, Item[[15]byte]{},
+		Item[[16]byte]{}, Item[[17]byte]{}, Item[[18]byte]{}, Item[[19]byte]{}, Item[[20]byte]{},
+	}
+
+	s := structsql.New()
+	var sql string
+	args := make([]any, 0, 10)
+
+	for _, row := range rows {
+		if err := s.Delete(row, &sql, &args); err != nil {
+			t.Fatalf("Delete error: %v", err)
+		}
+	}
+
+	// Every type is cached, so later calls allocate no more than the first one
+	first := testing.AllocsPerRun(100, func() {
+		_ = s.Delete(rows[0], &sql, &args)
+	})
+	last := testing.AllocsPerRun(100, func() {
+		_ = s.Delete(rows[len(rows)-1], &sql, &args)
+	})
+	if last != first {
+		t.Fatalf("Delete of the 20th type allocates %v times, want %v as a cache hit", last, first)
+	}
+}
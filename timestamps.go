@@ -0,0 +1,35 @@
+package structsql
+
+import "time"
+
+// NowFunc returns the current time used for autocreate and autoupdate columns.
+// Pass one to New to replace the default time.Now, eg: a fixed clock in tests.
+//
+//	type Post struct {
+//		ID        int       `db:"id,pk"`
+//		CreatedAt time.Time `db:"created_at,autocreate"` // set by Insert
+//		UpdatedAt time.Time `db:"updated_at,autoupdate"` // set by Insert and Update
+//	}
+type NowFunc func() time.Time
+
+// timestampValue returns the value bound for a timestamp column
+func (s *Structsql) timestampValue() any {
+	var v any = s.now()
+	s.bindTime(&v)
+	return v
+}
+
+// TimeFormat passed to New binds time.Time field values as strings in the given
+// layout instead of time.Time, eg: TimeFormat(time.RFC3339) for SQLite TEXT columns.
+// Empty, the default, leaves the value to the driver.
+type TimeFormat string
+
+// bindTime formats iface in place when it holds a time.Time and a TimeFormat is set
+func (s *Structsql) bindTime(iface *any) {
+	if s.timeFormat == "" {
+		return
+	}
+	if t, ok := (*iface).(time.Time); ok {
+		*iface = t.Format(string(s.timeFormat))
+	}
+}
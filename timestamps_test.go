@@ -0,0 +1,163 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cdvelop/structsql"
+)
+
+var fixedNow = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func fixedClock() time.Time {
+	return fixedNow
+}
+
+func TestInsertTimestamps(t *testing.T) {
+	p := Post{ID: 1, Title: "Hello"}
+	wantSQL := "INSERT INTO posts (id, title, created_at, updated_at) VALUES ($1, $2, $3, $4)"
+	wantArgs := []any{1, "Hello", fixedNow, fixedNow}
+
+	s := structsql.New(structsql.NowFunc(fixedClock))
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(p, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdateTimestamps(t *testing.T) {
+	p := Post{ID: 1, Title: "Hello", CreatedAt: fixedNow.Add(-time.Hour)}
+	wantSQL := "UPDATE posts SET title=$1, updated_at=$2 WHERE id=$3"
+	wantArgs := []any{"Hello", fixedNow, 1}
+
+	s := structsql.New(structsql.NowFunc(fixedClock))
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Update(p, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdateColumnsTimestamps(t *testing.T) {
+	p := Post{ID: 1, Title: "Hello"}
+	wantSQL := "UPDATE posts SET title=$1, updated_at=$2 WHERE id=$3"
+	wantArgs := []any{"Hello", fixedNow, 1}
+
+	s := structsql.New(structsql.NowFunc(fixedClock))
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.UpdateColumns(p, []string{"title"}, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("UpdateColumns error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("UpdateColumns SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("UpdateColumns args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestInsertTimeValue(t *testing.T) {
+	e := Event{ID: 1, Name: "launch", CreatedAt: fixedNow}
+	wantSQL := "INSERT INTO events (id, name, created_at) VALUES ($1, $2, $3)"
+	wantArgs := []any{1, "launch", fixedNow}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Insert(e, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Insert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	if _, ok := gotArgs[2].(time.Time); !ok {
+		t.Fatalf("Insert created_at bound as %T, want time.Time", gotArgs[2])
+	}
+}
+
+func TestTimeFormat(t *testing.T) {
+	s := structsql.New(structsql.SQLite, structsql.TimeFormat(time.RFC3339), structsql.NowFunc(fixedClock))
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	// Struct fields
+	e := Event{ID: 1, Name: "launch", CreatedAt: fixedNow}
+	if err := s.Insert(e, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	wantArgs := []any{1, "launch", "2024-01-02T03:04:05Z"}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	if err := s.Update(e, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+	wantArgs = []any{"launch", "2024-01-02T03:04:05Z", 1}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	// Timestamp columns
+	p := Post{ID: 1, Title: "Hello"}
+	if err := s.Insert(p, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	wantArgs = []any{1, "Hello", "2024-01-02T03:04:05Z", "2024-01-02T03:04:05Z"}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	// Caller supplied values
+	if err := s.UpdateMap("events", 1, map[string]any{"created_at": fixedNow}, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("UpdateMap error: %v", err)
+	}
+	wantArgs = []any{"2024-01-02T03:04:05Z", 1}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("UpdateMap args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	mysql := structsql.New(structsql.MySQL, structsql.TimeFormat(time.RFC3339))
+	if err := mysql.CallProc("close_events", []any{fixedNow}, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("CallProc error: %v", err)
+	}
+	wantArgs = []any{"2024-01-02T03:04:05Z"}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("CallProc args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
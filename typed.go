@@ -0,0 +1,28 @@
+package structsql
+
+import "github.com/cdvelop/tinyreflect"
+
+// Typed wrappers over the Structsql methods. The StructNamer constraint rejects
+// non-struct or unnamed inputs at compile time instead of returning an error.
+//
+// eg: structsql.Insert(s, User{ID: 1}, &sql, &values)
+
+// Insert is the typed form of (*Structsql).Insert
+func Insert[T tinyreflect.StructNamer](s *Structsql, row T, sql *string, values *[]any) error {
+	return s.Insert(row, sql, values)
+}
+
+// Update is the typed form of (*Structsql).Update
+func Update[T tinyreflect.StructNamer](s *Structsql, row T, sql *string, values *[]any) error {
+	return s.Update(row, sql, values)
+}
+
+// Delete is the typed form of (*Structsql).Delete
+func Delete[T tinyreflect.StructNamer](s *Structsql, row T, sql *string, values *[]any) error {
+	return s.Delete(row, sql, values)
+}
+
+// Select is the typed form of (*Structsql).Select
+func Select[T tinyreflect.StructNamer](s *Structsql, row T, sql *string, values *[]any) error {
+	return s.Select(row, sql, values)
+}
@@ -0,0 +1,72 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestTypedWrappers(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+
+	tests := []struct {
+		name     string
+		call     func(s *structsql.Structsql, sql *string, values *[]any) error
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "Insert",
+			call: func(s *structsql.Structsql, sql *string, values *[]any) error {
+				return structsql.Insert(s, u, sql, values)
+			},
+			wantSQL:  "INSERT INTO users (id, name, email) VALUES ($1, $2, $3)",
+			wantArgs: []any{1, "Alice", "alice@example.com"},
+		},
+		{
+			name: "Update",
+			call: func(s *structsql.Structsql, sql *string, values *[]any) error {
+				return structsql.Update(s, u, sql, values)
+			},
+			wantSQL:  "UPDATE users SET name=$1, email=$2 WHERE id=$3",
+			wantArgs: []any{"Alice", "alice@example.com", 1},
+		},
+		{
+			name: "Delete",
+			call: func(s *structsql.Structsql, sql *string, values *[]any) error {
+				return structsql.Delete(s, u, sql, values)
+			},
+			wantSQL:  "DELETE FROM users WHERE id=$1",
+			wantArgs: []any{1},
+		},
+		{
+			name: "Select",
+			call: func(s *structsql.Structsql, sql *string, values *[]any) error {
+				return structsql.Select(s, u, sql, values)
+			},
+			wantSQL:  "SELECT id, name, email FROM users WHERE id=$1",
+			wantArgs: []any{1},
+		},
+	}
+
+	s := structsql.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+
+			if err := tt.call(s, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("%s error: %v", tt.name, err)
+			}
+
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("%s SQL mismatch:\n got: %s\nwant: %s", tt.name, gotSQL, tt.wantSQL)
+			}
+
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("%s args mismatch:\n got: %v\nwant: %v", tt.name, gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -6,14 +6,52 @@ import (
 )
 
 func (s *Structsql) Update(structTable any, sql *string, values *[]any) error {
-	typ, err := s.validateStruct(structTable)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.update(structTable, false, sql, values)
+}
+
+// UpdateNonZero generates an UPDATE setting only the non primary key fields holding
+// a non zero value, for PATCH style updates from a partially filled struct, eg:
+// User{ID: 1, Name: "Alice"} generates UPDATE users SET name=$1 WHERE id=$2.
+// Update already skips zero fields, UpdateNonZero states it at the call site like
+// InsertNonZero. Zero means omit: a legitimate 0, "" or false can't be written
+// through this path, use UpdateColumns for it. Update timestamps are always set.
+func (s *Structsql) UpdateNonZero(structTable any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.update(structTable, false, sql, values)
+}
+
+// UpdateReturning generates an UPDATE followed by RETURNING with every column in
+// field order, eg: UPDATE users SET name=$1 WHERE id=$2 RETURNING id, name, email
+// Only PostgreSQL supports it, other database types return an error.
+func (s *Structsql) UpdateReturning(structTable any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.dbType != PostgreSQL {
+		return Err("returning not supported by database type", string(s.dbType))
+	}
+
+	return s.update(structTable, true, sql, values)
+}
+
+// update implements Update and UpdateReturning, the caller holds mu
+func (s *Structsql) update(structTable any, returning bool, sql *string, values *[]any) error {
+	typ, err := s.validateStruct(&structTable)
 	if err != nil {
 		return err
 	}
 
 	v := structTable
 
-	c := s.setupConv()
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
 
 	var tableStr string
 	s.getTableName(typ, &tableStr)
@@ -25,32 +63,41 @@ func (s *Structsql) Update(structTable any, sql *string, values *[]any) error {
 
 	numFields := len(info.fields)
 	if numFields == 0 {
-		return Err("struct has no fields")
+		return ErrNoFields
 	}
 
 	// Find primary key field index
-	idIndex, err := s.findIdField(tableStr, info.fields, true)
+	idIndex, err := s.keyIndex(info)
 	if err != nil {
 		return err
 	}
 
 	val := tinyreflect.ValueOf(v)
 
-	// Collect SET fields (non-zero, non-id)
-	var setColumns [32]string
-	var setCount int
+	// Optimistic locking version column, -1 when the struct has none
+	versionIndex := info.versionIndex()
+
+	// Collect SET fields (non-zero, non-id, non-version), creation timestamps are
+	// never updated and update timestamps are always refreshed
+	var buf [32]int // on the stack up to 32 columns
+	setFields := buf[:0]
 	for i := 0; i < numFields; i++ {
-		if i != idIndex {
-			fieldVal, err := val.Field(i)
+		f := &info.fields[i]
+		if i == idIndex || i == versionIndex || f.AutoCreate {
+			continue
+		}
+		if !f.AutoUpdate {
+			fieldVal, err := f.value(val)
 			if err != nil {
 				return err
 			}
-			if !fieldVal.IsZero() {
-				setColumns[setCount] = info.fields[i].Name
-				setCount++
+			if isZero(fieldVal) {
+				continue
 			}
 		}
+		setFields = append(setFields, i)
 	}
+	setCount := len(setFields)
 
 	if setCount == 0 {
 		return Err("no fields to update")
@@ -58,7 +105,7 @@ func (s *Structsql) Update(structTable any, sql *string, values *[]any) error {
 
 	// Build SQL
 	c.WrString(BuffOut, "UPDATE ")
-	c.WrString(BuffOut, tableStr)
+	s.quoteTable(tableStr, c)
 	c.WrString(BuffOut, " SET ")
 
 	// SET clauses
@@ -66,40 +113,441 @@ func (s *Structsql) Update(structTable any, sql *string, values *[]any) error {
 		if i > 0 {
 			c.WrString(BuffOut, ", ")
 		}
-		c.WrString(BuffOut, setColumns[i])
+		s.quote(info.fields[setFields[i]].Name, c)
+		c.WrString(BuffOut, "=")
+		s.placeholder(i+1, c)
+	}
+
+	s.writeUpdateWhere(c, info, idIndex, versionIndex, setCount+1)
+	if returning {
+		s.writeReturning(c, info)
+	}
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	// Populate values (only SET fields), then the ID and version
+	whereCount := 1
+	if versionIndex != -1 {
+		whereCount++
+	}
+	if err := s.ensureCapacity(values, setCount+whereCount); err != nil {
+		return err
+	}
+	for i := 0; i < setCount; i++ {
+		if err := s.appendSetValue(val, &info.fields[setFields[i]], values); err != nil {
+			return err
+		}
+	}
+	// Add ID and the current version at the end
+	return s.appendUpdateWhereValues(val, info, idIndex, versionIndex, values)
+}
+
+// UpdateColumns generates UPDATE users SET name=$1 WHERE id=$2 setting only the
+// given columns, zero values included. The primary key value is appended last.
+func (s *Structsql) UpdateColumns(structTable any, columns []string, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	if len(columns) == 0 {
+		return Err("no fields to update")
+	}
+
+	v := structTable
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	// Find primary key field index
+	idIndex, err := s.keyIndex(info)
+	if err != nil {
+		return err
+	}
+
+	versionIndex := info.versionIndex()
+
+	// Build SQL
+	c.WrString(BuffOut, "UPDATE ")
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " SET ")
+
+	// SET clauses, columns are validated against the struct
+	index := 1
+	for i, column := range columns {
+		colIndex := info.columnIndex(column)
+		if colIndex == -1 {
+			return errDetail(ErrUnknownColumn, column)
+		}
+		if colIndex == idIndex {
+			return Err("primary key can't be updated", column)
+		}
+		if colIndex == versionIndex {
+			return Err("version column is incremented automatically", column)
+		}
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[colIndex].Name, c)
+		c.WrString(BuffOut, "=")
+		s.placeholder(index, c)
+		index++
+	}
+
+	// Update timestamps are refreshed even when not requested
+	for i := range info.fields {
+		if info.fields[i].AutoUpdate && !containsColumn(columns, info.fields[i].Name) {
+			c.WrString(BuffOut, ", ")
+			s.quote(info.fields[i].Name, c)
+			c.WrString(BuffOut, "=")
+			s.placeholder(index, c)
+			index++
+		}
+	}
+
+	s.writeUpdateWhere(c, info, idIndex, versionIndex, index)
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	// Populate values in the requested column order, ID and version at the end
+	whereCount := 1
+	if versionIndex != -1 {
+		whereCount++
+	}
+	if err := s.ensureCapacity(values, index-1+whereCount); err != nil {
+		return err
+	}
+	val := tinyreflect.ValueOf(v)
+	for _, column := range columns {
+		if err := s.appendSetValue(val, &info.fields[info.columnIndex(column)], values); err != nil {
+			return err
+		}
+	}
+	for i := range info.fields {
+		if info.fields[i].AutoUpdate && !containsColumn(columns, info.fields[i].Name) {
+			*values = append(*values, s.timestampValue())
+		}
+	}
+
+	return s.appendUpdateWhereValues(val, info, idIndex, versionIndex, values)
+}
+
+// appendSetValue appends the value bound to a SET placeholder of f,
+// the current time for update timestamps or the struct field value.
+func (s *Structsql) appendSetValue(val tinyreflect.Value, f *fieldInfo, values *[]any) error {
+	if f.AutoUpdate {
+		*values = append(*values, s.timestampValue())
+		return nil
+	}
+	return s.appendFieldValue(val, f, values)
+}
+
+// appendFieldValue appends the value of the field f of the struct val, bound like
+// Insert binds it: JSON, arrays, Valuers, named basic types, times and bools.
+func (s *Structsql) appendFieldValue(val tinyreflect.Value, f *fieldInfo, values *[]any) error {
+	var iface any
+	if f.Array && s.dbType == PostgreSQL {
+		if err := bindArray(val, f, &iface); err != nil {
+			return err
+		}
+		*values = append(*values, iface)
+		return nil
+	}
+	if f.JSON || f.Array {
+		if err := s.bindJSON(val, f, &iface); err != nil {
+			return err
+		}
+		*values = append(*values, iface)
+		return nil
+	}
+	if f.direct(structBase(val), &iface) {
+		if err := bindValuer(&iface); err != nil {
+			return err
+		}
+		bindBasic(&iface)
+	} else {
+		fieldVal, err := f.value(val)
+		if err != nil {
+			return err
+		}
+		if err := bindValue(fieldVal, &iface); err != nil {
+			return err
+		}
+	}
+	s.bindTime(&iface)
+	s.bindBool(&iface)
+	*values = append(*values, iface)
+	return nil
+}
+
+// writeReturning writes " RETURNING id, name, email" listing every column of info
+func (s *Structsql) writeReturning(c *Conv, info *typeInfo) {
+	c.WrString(BuffOut, " RETURNING ")
+	for i := range info.fields {
+		if i > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.quote(info.fields[i].Name, c)
+	}
+}
+
+// containsColumn reports whether column is listed in columns
+func containsColumn(columns []string, column string) bool {
+	for _, c := range columns {
+		if c == column {
+			return true
+		}
+	}
+	return false
+}
+
+// writeUpdateWhere closes an UPDATE SET list with ", version=version+1" when the
+// struct has a version column, then writes " WHERE id=$n" plus " AND version=$n+1"
+// so the update only applies to the row version the caller read.
+func (s *Structsql) writeUpdateWhere(c *Conv, info *typeInfo, idIndex, versionIndex, index int) {
+	if versionIndex != -1 {
+		c.WrString(BuffOut, ", ")
+		s.quote(info.fields[versionIndex].Name, c)
 		c.WrString(BuffOut, "=")
-		s.dbType.placeholder(i+1, c)
+		s.quote(info.fields[versionIndex].Name, c)
+		c.WrString(BuffOut, "+1")
 	}
 
 	// WHERE
-	c.WrString(BuffOut, " WHERE id=")
-	s.dbType.placeholder(setCount+1, c)
+	c.WrString(BuffOut, " WHERE ")
+	s.quote(info.fields[idIndex].Name, c)
+	c.WrString(BuffOut, "=")
+	s.placeholder(index, c)
 
-	*sql = c.GetStringZeroCopy(BuffOut)
+	if versionIndex != -1 {
+		c.WrString(BuffOut, " AND ")
+		s.quote(info.fields[versionIndex].Name, c)
+		c.WrString(BuffOut, "=")
+		s.placeholder(index+1, c)
+	}
+}
 
-	// Populate values (only non-zero SET fields)
-	*values = (*values)[:0]
-	for i := 0; i < numFields; i++ {
-		if i != idIndex {
-			fieldVal, err := val.Field(i)
-			if err != nil {
+// appendUpdateWhereValues appends the primary key value and, when present, the
+// current version value matching the placeholders written by writeUpdateWhere.
+func (s *Structsql) appendUpdateWhereValues(val tinyreflect.Value, info *typeInfo, idIndex, versionIndex int, values *[]any) error {
+	fieldVal, err := info.fields[idIndex].value(val)
+	if err != nil {
+		return err
+	}
+	var iface any
+	if err := bindValue(fieldVal, &iface); err != nil {
+		return err
+	}
+	s.appendArgs(values, iface)
+
+	if versionIndex != -1 {
+		fieldVal, err := info.fields[versionIndex].value(val)
+		if err != nil {
+			return err
+		}
+		var iface any
+		if err := bindValue(fieldVal, &iface); err != nil {
+			return err
+		}
+		s.appendArgs(values, iface)
+	}
+
+	return nil
+}
+
+// UpdateBatch generates a single UPDATE setting every non primary key column of
+// each struct in a slice, rows are matched on their primary key:
+//
+//	UPDATE users SET name=CASE id WHEN $1 THEN $2 WHEN $3 THEN $4 ELSE name END,
+//	email=CASE id WHEN $5 THEN $6 WHEN $7 THEN $8 ELSE email END WHERE id IN ($9, $10)
+//
+// Unlike Update zero values are written. The ELSE branch gives the placeholders the
+// column type on PostgreSQL. Structs with a version column are rejected since each
+// row would need its own version check, use Update for them.
+func (s *Structsql) UpdateBatch(structSlice any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if structSlice == nil {
+		return ErrNilInput
+	}
+
+	sliceTyp := tinyreflect.TypeOf(structSlice)
+	if sliceTyp.Kind() != K.Slice {
+		return Err("input is not a slice")
+	}
+
+	rows := tinyreflect.ValueOf(structSlice)
+	numRows, err := rows.Len()
+	if err != nil {
+		return err
+	}
+	if numRows == 0 {
+		return Err("empty slice provided")
+	}
+
+	// Validate the element type through the first row
+	first, err := rows.Index(0)
+	if err != nil {
+		return err
+	}
+	row, err := first.Interface()
+	if err != nil {
+		return err
+	}
+	typ, err := s.validateStruct(&row)
+	if err != nil {
+		return err
+	}
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	idIndex, err := s.keyIndex(info)
+	if err != nil {
+		return err
+	}
+
+	if info.versionIndex() != -1 {
+		return Err("batch update not supported with version column", info.fields[info.versionIndex()].Name)
+	}
+
+	// Resolve every row once, []*User rows are dereferenced
+	rowVals := make([]tinyreflect.Value, numRows)
+	for r := 0; r < numRows; r++ {
+		rowVal, err := rows.Index(r)
+		if err != nil {
+			return err
+		}
+		if rowVal.Kind() == K.Pointer {
+			if rowVal, err = rowVal.Elem(); err != nil {
+				return err
+			}
+			if rowVal.Type() == nil {
+				return ErrNilPointer
+			}
+		}
+		// rows are read from the caller's slice, bind a copy
+		if rowVal, err = copyValue(rowVal); err != nil {
+			return err
+		}
+		rowVals[r] = rowVal
+	}
+
+	// Build SQL
+	c.WrString(BuffOut, "UPDATE ")
+	s.quoteTable(tableStr, c)
+	c.WrString(BuffOut, " SET ")
+
+	// Every SET column binds a key and a value per row, then the keys of IN
+	setFields := 0
+	for i := range info.fields {
+		f := &info.fields[i]
+		if i != idIndex && !f.Auto && !f.AutoCreate {
+			setFields++
+		}
+	}
+	if setFields == 0 {
+		return Err("no fields to update")
+	}
+	if err := s.ensureCapacity(values, (setFields*2+1)*numRows); err != nil {
+		return err
+	}
+
+	index := 1
+	setCount := 0
+	for i := range info.fields {
+		f := &info.fields[i]
+		if i == idIndex || f.Auto || f.AutoCreate {
+			continue
+		}
+		if setCount > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		setCount++
+
+		s.quote(f.Name, c)
+		c.WrString(BuffOut, "=CASE ")
+		s.quote(info.fields[idIndex].Name, c)
+		for r := 0; r < numRows; r++ {
+			c.WrString(BuffOut, " WHEN ")
+			s.placeholder(index, c)
+			c.WrString(BuffOut, " THEN ")
+			s.placeholder(index+1, c)
+			index += 2
+
+			if err := s.appendBatchKey(rowVals[r], info, idIndex, values); err != nil {
 				return err
 			}
-			if !fieldVal.IsZero() {
-				var iface any
-				fieldVal.InterfaceZeroAlloc(&iface)
-				*values = append(*values, iface)
+			if err := s.appendSetValue(rowVals[r], f, values); err != nil {
+				return err
 			}
 		}
+		c.WrString(BuffOut, " ELSE ")
+		s.quote(f.Name, c)
+		c.WrString(BuffOut, " END")
 	}
-	// Add ID at the end
-	fieldVal, err := val.Field(idIndex)
+
+	// WHERE id IN (...)
+	c.WrString(BuffOut, " WHERE ")
+	s.quote(info.fields[idIndex].Name, c)
+	c.WrString(BuffOut, " IN (")
+	for r := 0; r < numRows; r++ {
+		if r > 0 {
+			c.WrString(BuffOut, ", ")
+		}
+		s.placeholder(index, c)
+		index++
+		if err := s.appendBatchKey(rowVals[r], info, idIndex, values); err != nil {
+			return err
+		}
+	}
+	c.WrString(BuffOut, ")")
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// appendBatchKey appends the primary key value of the row val
+func (s *Structsql) appendBatchKey(val tinyreflect.Value, info *typeInfo, idIndex int, values *[]any) error {
+	fieldVal, err := info.fields[idIndex].value(val)
 	if err != nil {
 		return err
 	}
 	var iface any
-	fieldVal.InterfaceZeroAlloc(&iface)
-	*values = append(*values, iface)
-
+	if err := bindValue(fieldVal, &iface); err != nil {
+		return err
+	}
+	s.appendArgs(values, iface)
 	return nil
 }
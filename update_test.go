@@ -9,7 +9,7 @@ import (
 
 func TestUpdate(t *testing.T) {
 	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
-	wantSQL := "UPDATE user SET name=$1, email=$2 WHERE id=$3"
+	wantSQL := "UPDATE users SET name=$1, email=$2 WHERE id=$3"
 	wantArgs := []any{"Alice", "alice@example.com", 1}
 
 	s := structsql.New() // Default PostgreSQL
@@ -32,7 +32,7 @@ func TestUpdate(t *testing.T) {
 
 func TestUpdateSQLite(t *testing.T) {
 	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
-	wantSQL := "UPDATE user SET name=?, email=? WHERE id=?"
+	wantSQL := "UPDATE users SET name=?, email=? WHERE id=?"
 	wantArgs := []any{"Alice", "alice@example.com", 1}
 
 	s := structsql.New(structsql.SQLite)
@@ -53,9 +53,67 @@ func TestUpdateSQLite(t *testing.T) {
 	}
 }
 
+func TestUpdateReturning(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "UPDATE users SET name=$1, email=$2 WHERE id=$3 RETURNING id, name, email"
+	wantArgs := []any{"Alice", "alice@example.com", 1}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.UpdateReturning(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("UpdateReturning error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("UpdateReturning SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("UpdateReturning args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	if err := structsql.New(structsql.MySQL).UpdateReturning(u, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("UpdateReturning expected error for MySQL, got nil")
+	}
+}
+
+func TestUpdatePKLast(t *testing.T) {
+	tests := []struct {
+		name     string
+		row      any
+		wantSQL  string
+		wantArgs []any
+	}{
+		{"pk", Transfer{Memo: "rent", Amount: 500, ID: 9},
+			"UPDATE transfers SET memo=$1, amount=$2 WHERE id=$3", []any{"rent", 500, 9}},
+		{"auto pk", Entry{Memo: "rent", Amount: 500, ID: 9},
+			"UPDATE entries SET memo=$1, amount=$2 WHERE id=$3", []any{"rent", 500, 9}},
+	}
+
+	s := structsql.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSQL string
+			gotArgs := make([]any, 0, 10)
+			if err := s.Update(tt.row, &gotSQL, &gotArgs); err != nil {
+				t.Fatalf("Update error: %v", err)
+			}
+			if gotSQL != tt.wantSQL {
+				t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(gotArgs, tt.wantArgs) {
+				t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, tt.wantArgs)
+			}
+		})
+	}
+}
+
 func TestUpdatePartial(t *testing.T) {
 	u := User{ID: 1, Email: "alice@example.com"} // Name is zero value ""
-	wantSQL := "UPDATE user SET email=$1 WHERE id=$2"
+	wantSQL := "UPDATE users SET email=$1 WHERE id=$2"
 	wantArgs := []any{"alice@example.com", 1}
 
 	s := structsql.New() // Default PostgreSQL
@@ -76,9 +134,36 @@ func TestUpdatePartial(t *testing.T) {
 	}
 }
 
+func TestUpdateNonZero(t *testing.T) {
+	u := User{ID: 1, Name: "Alice"} // Email is zero value ""
+	wantSQL := "UPDATE users SET name=$1 WHERE id=$2"
+	wantArgs := []any{"Alice", 1}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.UpdateNonZero(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("UpdateNonZero error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("UpdateNonZero SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("UpdateNonZero args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	if err := s.UpdateNonZero(User{ID: 1}, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("UpdateNonZero expected error without non zero fields, got nil")
+	}
+}
+
 func TestUpdatePartialSQLite(t *testing.T) {
 	u := User{ID: 1, Email: "alice@example.com"} // Name is zero value ""
-	wantSQL := "UPDATE user SET email=? WHERE id=?"
+	wantSQL := "UPDATE users SET email=? WHERE id=?"
 	wantArgs := []any{"alice@example.com", 1}
 
 	s := structsql.New(structsql.SQLite)
@@ -99,6 +184,306 @@ func TestUpdatePartialSQLite(t *testing.T) {
 	}
 }
 
+func TestUpdateMySQL(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "UPDATE `users` SET `name`=?, `email`=? WHERE `id`=?"
+	wantArgs := []any{"Alice", "alice@example.com", 1}
+
+	s := structsql.New(structsql.MySQL)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Update(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdateSQLServer(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "UPDATE [users] SET [name]=@p1, [email]=@p2 WHERE [id]=@p3"
+	wantArgs := []any{"Alice", "alice@example.com", 1}
+
+	s := structsql.New(structsql.SQLServer)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Update(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdateCustomPK(t *testing.T) {
+	p := Profile{UserID: 7, FirstName: "Alice"}
+	wantSQL := "UPDATE profiles SET first_name=$1 WHERE user_id=$2"
+	wantArgs := []any{"Alice", 7}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Update(p, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdateExcludedField(t *testing.T) {
+	m := Member{ID: 1, Password: "secret", Email: "alice@example.com"}
+	wantSQL := "UPDATE members SET email=$1 WHERE id=$2"
+	wantArgs := []any{"alice@example.com", 1}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Update(m, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdatePointer(t *testing.T) {
+	u := &User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "UPDATE users SET name=$1, email=$2 WHERE id=$3"
+	wantArgs := []any{"Alice", "alice@example.com", 1}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Update(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdatePointerField(t *testing.T) {
+	phone := "555-0100"
+	c := Contact{ID: 1, Phone: &phone}
+	wantSQL := "UPDATE contacts SET phone=$1 WHERE id=$2"
+	wantArgs := []any{"555-0100", 1}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Update(c, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdateColumns(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: ""}
+	wantSQL := "UPDATE users SET email=$1, name=$2 WHERE id=$3"
+	wantArgs := []any{"", "Alice", 1}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.UpdateColumns(u, []string{"email", "name"}, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("UpdateColumns error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("UpdateColumns SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("UpdateColumns args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdateColumnsInvalid(t *testing.T) {
+	u := User{ID: 1, Name: "Alice"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	for _, columns := range [][]string{nil, {"password"}, {"name", "id"}} {
+		if err := s.UpdateColumns(u, columns, &gotSQL, &gotArgs); err == nil {
+			t.Fatalf("UpdateColumns expected error for columns %v, got SQL: %s", columns, gotSQL)
+		}
+	}
+}
+
+func TestUpdateVersion(t *testing.T) {
+	d := Document{ID: 1, Title: "Draft", Version: 3}
+	wantSQL := "UPDATE documents SET title=$1, version=version+1 WHERE id=$2 AND version=$3"
+	wantArgs := []any{"Draft", 1, 3}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Update(d, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdateColumnsVersion(t *testing.T) {
+	d := Document{ID: 1, Version: 3}
+	wantSQL := "UPDATE documents SET title=$1, version=version+1 WHERE id=$2 AND version=$3"
+	wantArgs := []any{"", 1, 3}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.UpdateColumns(d, []string{"title"}, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("UpdateColumns error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("UpdateColumns SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("UpdateColumns args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdateEmbedded(t *testing.T) {
+	a := Article{Model: Model{ID: 1}, Title: "Hello"}
+	wantSQL := "UPDATE articles SET title=$1 WHERE id=$2"
+	wantArgs := []any{"Hello", 1}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Update(a, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Update SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpdateBatch(t *testing.T) {
+	users := []User{
+		{ID: 1, Name: "Alice", Email: "alice@example.com"},
+		{ID: 2, Name: "Bob", Email: ""},
+	}
+	wantArgs := []any{1, "Alice", 2, "Bob", 1, "alice@example.com", 2, "", 1, 2}
+
+	tests := []struct {
+		db      any
+		wantSQL string
+	}{
+		{structsql.PostgreSQL, "UPDATE users SET name=CASE id WHEN $1 THEN $2 WHEN $3 THEN $4 ELSE name END, " +
+			"email=CASE id WHEN $5 THEN $6 WHEN $7 THEN $8 ELSE email END WHERE id IN ($9, $10)"},
+		{structsql.MySQL, "UPDATE `users` SET `name`=CASE `id` WHEN ? THEN ? WHEN ? THEN ? ELSE `name` END, " +
+			"`email`=CASE `id` WHEN ? THEN ? WHEN ? THEN ? ELSE `email` END WHERE `id` IN (?, ?)"},
+	}
+
+	for _, tt := range tests {
+		s := structsql.New(tt.db)
+		var gotSQL string
+		gotArgs := make([]any, 0, 10)
+
+		if err := s.UpdateBatch(users, &gotSQL, &gotArgs); err != nil {
+			t.Fatalf("UpdateBatch error: %v", err)
+		}
+
+		if gotSQL != tt.wantSQL {
+			t.Fatalf("UpdateBatch SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+		}
+
+		if !reflect.DeepEqual(gotArgs, wantArgs) {
+			t.Fatalf("UpdateBatch args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+		}
+	}
+}
+
+func TestUpdateBatchInvalid(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.UpdateBatch([]User{}, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("UpdateBatch expected error for empty slice, got nil")
+	}
+
+	if err := s.UpdateBatch(User{ID: 1}, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("UpdateBatch expected error for non slice input, got nil")
+	}
+
+	docs := []Document{{ID: 1, Title: "a", Version: 1}}
+	if err := s.UpdateBatch(docs, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("UpdateBatch expected error for version column, got nil")
+	}
+}
+
 func BenchmarkUpdate(b *testing.B) {
 	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
 	s := structsql.New()
@@ -122,3 +507,17 @@ func BenchmarkUpdateWithArgs(b *testing.B) {
 		_ = s.Update(u, &sql, &args)
 	}
 }
+
+// BenchmarkUpdateRepeated updates a struct whose primary key is found by naming
+// convention, the detection runs once per type and is then read from the cache
+func BenchmarkUpdateRepeated(b *testing.B) {
+	p := Product{IDProduct: 1, Name: "Lamp"}
+	s := structsql.New()
+	var sql string
+	args := make([]any, 0, 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		args = args[:0]
+		_ = s.Update(p, &sql, &args)
+	}
+}
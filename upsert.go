@@ -0,0 +1,175 @@
+package structsql
+
+import (
+	. "github.com/cdvelop/tinystring"
+)
+
+// Upsert generates an INSERT that updates every non primary key column when the
+// primary key already exists:
+//
+//	PostgreSQL: INSERT ... ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name
+//	SQLite:     INSERT ... ON CONFLICT(id) DO UPDATE SET name=excluded.name
+//	MySQL:      INSERT ... ON DUPLICATE KEY UPDATE name=VALUES(name)
+//
+// The update side references the inserted row, so values only holds the insert values.
+func (s *Structsql) Upsert(structTable any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	v := structTable
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	numFields := len(info.fields)
+	if numFields == 0 {
+		return ErrNoFields
+	}
+
+	// Find primary key field index, used as conflict target
+	idIndex, err := info.primaryKey()
+	if err != nil {
+		return err
+	}
+
+	// SET columns: the inserted non-id fields, auto generated columns are left out
+	// of the INSERT like writeInsert does and creation timestamps are kept
+	setFields := 0
+	for i := 0; i < numFields; i++ {
+		f := &info.fields[i]
+		if i != idIndex && !f.Auto && !f.AutoCreate {
+			setFields++
+		}
+	}
+	if setFields == 0 {
+		return Err("no fields to update")
+	}
+
+	switch s.dbType {
+	case PostgreSQL, SQLite, MySQL:
+	default:
+		return Err("upsert not supported by database type", string(s.dbType))
+	}
+
+	if err := s.writeInsert(c, "INSERT INTO ", tableStr, info, v, values); err != nil {
+		return err
+	}
+
+	// Conflict clause
+	switch s.dbType {
+	case PostgreSQL:
+		c.WrString(BuffOut, " ON CONFLICT (")
+		s.quote(info.fields[idIndex].Name, c)
+		c.WrString(BuffOut, ") DO UPDATE SET ")
+	case SQLite:
+		c.WrString(BuffOut, " ON CONFLICT(")
+		s.quote(info.fields[idIndex].Name, c)
+		c.WrString(BuffOut, ") DO UPDATE SET ")
+	case MySQL:
+		c.WrString(BuffOut, " ON DUPLICATE KEY UPDATE ")
+	}
+
+	// SET clauses
+	first := true
+	for i := 0; i < numFields; i++ {
+		f := &info.fields[i]
+		if i == idIndex || f.Auto || f.AutoCreate {
+			continue
+		}
+		if !first {
+			c.WrString(BuffOut, ", ")
+		}
+		first = false
+
+		name := info.fields[i].Name
+		s.quote(name, c)
+		c.WrString(BuffOut, "=")
+		switch s.dbType {
+		case PostgreSQL:
+			c.WrString(BuffOut, "EXCLUDED.")
+			s.quote(name, c)
+		case SQLite:
+			c.WrString(BuffOut, "excluded.")
+			s.quote(name, c)
+		case MySQL:
+			c.WrString(BuffOut, "VALUES(")
+			s.quote(name, c)
+			c.WrString(BuffOut, ")")
+		}
+	}
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// InsertOrIgnore generates an INSERT that skips rows conflicting with an existing
+// primary key or unique constraint:
+//
+//	PostgreSQL, SQLite: INSERT ... ON CONFLICT DO NOTHING
+//	MySQL:              INSERT IGNORE INTO ...
+func (s *Structsql) InsertOrIgnore(structTable any, sql *string, values *[]any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	typ, err := s.validateStruct(&structTable)
+	if err != nil {
+		return err
+	}
+
+	v := structTable
+
+	c, err := s.setupConv()
+	if err != nil {
+		return err
+	}
+
+	var tableStr string
+	s.getTableName(typ, &tableStr)
+
+	info, err := s.getTypeInfo(typ)
+	if err != nil {
+		return err
+	}
+
+	if len(info.fields) == 0 {
+		return ErrNoFields
+	}
+
+	switch s.dbType {
+	case PostgreSQL, SQLite:
+		if err := s.writeInsert(c, "INSERT INTO ", tableStr, info, v, values); err != nil {
+			return err
+		}
+		c.WrString(BuffOut, " ON CONFLICT DO NOTHING")
+	case MySQL:
+		if err := s.writeInsert(c, "INSERT IGNORE INTO ", tableStr, info, v, values); err != nil {
+			return err
+		}
+	default:
+		return Err("insert or ignore not supported by database type", string(s.dbType))
+	}
+
+	if err := s.setSQL(c, sql); err != nil {
+		return err
+	}
+
+	return nil
+}
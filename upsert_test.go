@@ -0,0 +1,172 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestUpsert(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES ($1, $2, $3) ON CONFLICT (id) DO UPDATE SET name=EXCLUDED.name, email=EXCLUDED.email"
+	wantArgs := []any{1, "Alice", "alice@example.com"}
+
+	s := structsql.New() // Default PostgreSQL
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Upsert(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Upsert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Upsert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Upsert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpsertSQLite(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO users (id, name, email) VALUES (?, ?, ?) ON CONFLICT(id) DO UPDATE SET name=excluded.name, email=excluded.email"
+	wantArgs := []any{1, "Alice", "alice@example.com"}
+
+	s := structsql.New(structsql.SQLite)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Upsert(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Upsert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Upsert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Upsert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpsertMySQL(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantSQL := "INSERT INTO `users` (`id`, `name`, `email`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `name`=VALUES(`name`), `email`=VALUES(`email`)"
+
+	s := structsql.New(structsql.MySQL)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.Upsert(u, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("Upsert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Upsert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+}
+
+func TestUpsertSQLServerUnsupported(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+
+	s := structsql.New(structsql.SQLServer)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Upsert(u, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("Upsert expected error for SQL Server, got nil")
+	}
+}
+
+func TestUpsertAutoColumn(t *testing.T) {
+	r := Receipt{ID: 1, Seq: 9, Memo: "paid"}
+	wantSQL := "INSERT INTO receipts (id, memo) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET memo=EXCLUDED.memo"
+	wantArgs := []any{1, "paid"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Upsert(r, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Upsert error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("Upsert SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Upsert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestUpsertNoSetColumns(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Upsert(Visit{ID: 1}, &gotSQL, &gotArgs); err == nil {
+		t.Fatalf("Upsert expected error without SET columns, got SQL: %s", gotSQL)
+	}
+}
+
+func TestInsertOrIgnore(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	wantArgs := []any{1, "Alice", "alice@example.com"}
+
+	tests := []struct {
+		db      any
+		wantSQL string
+	}{
+		{structsql.PostgreSQL, "INSERT INTO users (id, name, email) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING"},
+		{structsql.SQLite, "INSERT INTO users (id, name, email) VALUES (?, ?, ?) ON CONFLICT DO NOTHING"},
+		{structsql.MySQL, "INSERT IGNORE INTO `users` (`id`, `name`, `email`) VALUES (?, ?, ?)"},
+	}
+
+	for _, tt := range tests {
+		s := structsql.New(tt.db)
+		var gotSQL string
+		gotArgs := make([]any, 0, 10)
+
+		if err := s.InsertOrIgnore(u, &gotSQL, &gotArgs); err != nil {
+			t.Fatalf("InsertOrIgnore error: %v", err)
+		}
+
+		if gotSQL != tt.wantSQL {
+			t.Fatalf("InsertOrIgnore SQL mismatch:\n got: %s\nwant: %s", gotSQL, tt.wantSQL)
+		}
+
+		if !reflect.DeepEqual(gotArgs, wantArgs) {
+			t.Fatalf("InsertOrIgnore args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+		}
+	}
+}
+
+func TestInsertOrIgnoreSQLServerUnsupported(t *testing.T) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+
+	s := structsql.New(structsql.SQLServer)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.InsertOrIgnore(u, &gotSQL, &gotArgs); err == nil {
+		t.Fatal("InsertOrIgnore expected error for SQL Server, got nil")
+	}
+}
+
+func BenchmarkUpsert(b *testing.B) {
+	u := User{ID: 1, Name: "Alice", Email: "alice@example.com"}
+	s := structsql.New()
+	var sql string
+	args := make([]any, 0, 10)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		args = args[:0]
+		_ = s.Upsert(u, &sql, &args)
+	}
+}
@@ -0,0 +1,18 @@
+package structsql
+
+import "database/sql/driver"
+
+// bindValuer replaces a value implementing driver.Valuer, eg: a money or enum type,
+// with the result of its Value method so values hold what the driver binds.
+func bindValuer(iface *any) error {
+	valuer, ok := (*iface).(driver.Valuer)
+	if !ok {
+		return nil
+	}
+	v, err := valuer.Value()
+	if err != nil {
+		return err
+	}
+	*iface = v
+	return nil
+}
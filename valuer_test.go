@@ -0,0 +1,47 @@
+package structsql_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestValuer(t *testing.T) {
+	inv := Invoice{ID: 1, Status: StatusPaid}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Insert(inv, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Insert error: %v", err)
+	}
+	wantArgs := []any{1, "paid"}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Insert args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+
+	if err := s.Update(inv, &gotSQL, &gotArgs); err != nil {
+		t.Fatalf("Update error: %v", err)
+	}
+	wantArgs = []any{"paid", 1}
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("Update args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestValuerError(t *testing.T) {
+	inv := Invoice{ID: 1, Status: Status(9)}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.Insert(inv, &gotSQL, &gotArgs); err == nil || err.Error() != "invalid status" {
+		t.Fatalf("Insert error = %v, want invalid status", err)
+	}
+	if err := s.Update(inv, &gotSQL, &gotArgs); err == nil || err.Error() != "invalid status" {
+		t.Fatalf("Update error = %v, want invalid status", err)
+	}
+}
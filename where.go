@@ -0,0 +1,134 @@
+package structsql
+
+import . "github.com/cdvelop/tinystring"
+
+// Where collects conditions joined with AND for SelectWhere, CountWhere and DeleteWhere.
+// Columns are validated against the struct when the statement is built, eg:
+//
+//	w := structsql.NewWhere().Eq("name", "Alice").Gt("age", 18)
+//	// WHERE name=$1 AND age>$2
+type Where struct {
+	conds []condition
+}
+
+type condition struct {
+	column string
+	op     string // "=", ">", "<", "LIKE" or "IN"
+	value  any
+	list   []any // IN values
+}
+
+// NewWhere returns an empty Where ready to chain conditions.
+func NewWhere() *Where {
+	return &Where{}
+}
+
+// Eq adds column=value
+func (w *Where) Eq(column string, value any) *Where {
+	return w.add(column, "=", value, nil)
+}
+
+// Gt adds column>value
+func (w *Where) Gt(column string, value any) *Where {
+	return w.add(column, ">", value, nil)
+}
+
+// Lt adds column<value
+func (w *Where) Lt(column string, value any) *Where {
+	return w.add(column, "<", value, nil)
+}
+
+// Like adds column LIKE pattern
+func (w *Where) Like(column string, pattern string) *Where {
+	return w.add(column, "LIKE", pattern, nil)
+}
+
+// In adds column IN (values...) with one placeholder per value
+func (w *Where) In(column string, values ...any) *Where {
+	return w.add(column, "IN", nil, values)
+}
+
+// argCount returns the number of values writeWhere appends for w, nil included
+func (w *Where) argCount() int {
+	if w == nil {
+		return 0
+	}
+	n := 0
+	for i := range w.conds {
+		if w.conds[i].op == "IN" {
+			n += len(w.conds[i].list)
+		} else {
+			n++
+		}
+	}
+	return n
+}
+
+func (w *Where) add(column, op string, value any, list []any) *Where {
+	w.conds = append(w.conds, condition{column: column, op: op, value: value, list: list})
+	return w
+}
+
+// writeWhere writes " WHERE a=$n AND b>$n+1" into BuffOut numbering placeholders from index,
+// appends the bound values in clause order and returns the next free placeholder index.
+// Nothing is written for a nil or empty Where.
+func (s *Structsql) writeWhere(c *Conv, info *typeInfo, w *Where, index int, values *[]any) (int, error) {
+	if w == nil {
+		return index, nil
+	}
+
+	for i := range w.conds {
+		cond := &w.conds[i]
+
+		colIndex := info.columnIndex(cond.column)
+		if colIndex == -1 {
+			return index, errDetail(ErrUnknownColumn, cond.column)
+		}
+
+		if i == 0 {
+			c.WrString(BuffOut, " WHERE ")
+		} else {
+			c.WrString(BuffOut, " AND ")
+		}
+		s.quote(info.fields[colIndex].Name, c)
+
+		switch cond.op {
+		case "IN":
+			if len(cond.list) == 0 {
+				return index, Err("no values provided for", cond.column)
+			}
+			c.WrString(BuffOut, " IN (")
+			for j := range cond.list {
+				if j > 0 {
+					c.WrString(BuffOut, ", ")
+				}
+				s.placeholder(index, c)
+				index++
+			}
+			c.WrString(BuffOut, ")")
+			s.appendArgs(values, cond.list...)
+		case "LIKE":
+			c.WrString(BuffOut, " LIKE ")
+			s.placeholder(index, c)
+			index++
+			s.appendArgs(values, cond.value)
+		default:
+			c.WrString(BuffOut, cond.op)
+			s.placeholder(index, c)
+			index++
+			s.appendArgs(values, cond.value)
+		}
+	}
+
+	return index, nil
+}
+
+// appendArgs appends args to values bound like the struct fields they are compared
+// with: times formatted by TimeFormat and bools as integers with BoolAsInt.
+func (s *Structsql) appendArgs(values *[]any, args ...any) {
+	for _, arg := range args {
+		s.bindTime(&arg)
+		s.bindBool(&arg)
+		*values = append(*values, arg)
+	}
+}
@@ -0,0 +1,123 @@
+package structsql_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cdvelop/structsql"
+)
+
+func TestSelectWhere(t *testing.T) {
+	w := structsql.NewWhere().Eq("name", "Alice").Gt("id", 10).Like("email", "%@example.com")
+	wantSQL := "SELECT id, name, email FROM users WHERE name=$1 AND id>$2 AND email LIKE $3"
+	wantArgs := []any{"Alice", 10, "%@example.com"}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.SelectWhere(User{}, w, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("SelectWhere error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("SelectWhere SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("SelectWhere args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestCountWhereIn(t *testing.T) {
+	w := structsql.NewWhere().In("id", 1, 2, 3).Lt("id", 100).Eq("name", "Bob")
+	wantSQL := "SELECT COUNT(*) FROM users WHERE id IN (?, ?, ?) AND id<? AND name=?"
+	wantArgs := []any{1, 2, 3, 100, "Bob"}
+
+	s := structsql.New(structsql.SQLite)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.CountWhere(User{}, w, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("CountWhere error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("CountWhere SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("CountWhere args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestDeleteWhere(t *testing.T) {
+	w := structsql.NewWhere().Eq("email", "old@example.com").In("id", 4, 5).Gt("id", 3)
+	wantSQL := "DELETE FROM users WHERE email=$1 AND id IN ($2, $3) AND id>$4"
+	wantArgs := []any{"old@example.com", 4, 5, 3}
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.DeleteWhere(User{}, w, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("DeleteWhere error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("DeleteWhere SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if !reflect.DeepEqual(gotArgs, wantArgs) {
+		t.Fatalf("DeleteWhere args mismatch:\n got: %v\nwant: %v", gotArgs, wantArgs)
+	}
+}
+
+func TestDeleteWhereEmpty(t *testing.T) {
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	for _, w := range []*structsql.Where{nil, structsql.NewWhere()} {
+		if err := s.DeleteWhere(User{}, w, &gotSQL, &gotArgs); !errors.Is(err, structsql.ErrFullTableDelete) {
+			t.Fatalf("DeleteWhere error = %v, want ErrFullTableDelete", err)
+		}
+	}
+}
+
+func TestDeleteWhereAllowFullTable(t *testing.T) {
+	wantSQL := "DELETE FROM users"
+
+	s := structsql.New(structsql.AllowFullTableDelete)
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	err := s.DeleteWhere(User{}, nil, &gotSQL, &gotArgs)
+	if err != nil {
+		t.Fatalf("DeleteWhere error: %v", err)
+	}
+
+	if gotSQL != wantSQL {
+		t.Fatalf("DeleteWhere SQL mismatch:\n got: %s\nwant: %s", gotSQL, wantSQL)
+	}
+
+	if len(gotArgs) != 0 {
+		t.Fatalf("DeleteWhere args mismatch:\n got: %v\nwant: []", gotArgs)
+	}
+}
+
+func TestWhereUnknownColumn(t *testing.T) {
+	w := structsql.NewWhere().Eq("password", "secret")
+
+	s := structsql.New()
+	var gotSQL string
+	gotArgs := make([]any, 0, 10)
+
+	if err := s.SelectWhere(User{}, w, &gotSQL, &gotArgs); err == nil {
+		t.Fatalf("SelectWhere expected error for unknown column, got SQL: %s", gotSQL)
+	}
+}